@@ -0,0 +1,66 @@
+package ecsbit
+
+import "github.com/atEaE/ecsbit/internal/bits"
+
+// Access : Systemが参照、更新するComponentの宣言
+// Schedulerは宣言を元に、並列に実行しても競合しないSystemを判定します
+type Access struct {
+	reads          bits.Mask256 // 参照するComponent
+	writes         bits.Mask256 // 更新するComponent
+	resourceReads  bits.Mask256 // 参照するResource
+	resourceWrites bits.Mask256 // 更新するResource
+}
+
+// NewAccess : 何も参照、更新しないAccessを生成します
+func NewAccess() Access {
+	return Access{}
+}
+
+// Read : 参照するComponentを追加します
+func (a Access) Read(ids ...ComponentID) Access {
+	for _, id := range ids {
+		a.reads.Set(uint32(id), true)
+	}
+	return a
+}
+
+// Write : 更新するComponentを追加します（更新するComponentは参照も可能です）
+func (a Access) Write(ids ...ComponentID) Access {
+	for _, id := range ids {
+		a.writes.Set(uint32(id), true)
+	}
+	return a
+}
+
+// ReadResource : 参照するResourceを追加します
+func (a Access) ReadResource(ids ...ResourceID) Access {
+	for _, id := range ids {
+		a.resourceReads.Set(uint32(id), true)
+	}
+	return a
+}
+
+// WriteResource : 更新するResourceを追加します（更新するResourceは参照も可能です）
+func (a Access) WriteResource(ids ...ResourceID) Access {
+	for _, id := range ids {
+		a.resourceWrites.Set(uint32(id), true)
+	}
+	return a
+}
+
+// Conflicts : 並列に実行すると競合するかどうかを返します
+// どちらかが更新するComponentもしくはResourceを、もう一方が参照もしくは更新する場合に競合します
+func (a *Access) Conflicts(other *Access) bool {
+	return a.writes.Intersects(&other.writes) ||
+		a.writes.Intersects(&other.reads) ||
+		a.reads.Intersects(&other.writes) ||
+		a.resourceWrites.Intersects(&other.resourceWrites) ||
+		a.resourceWrites.Intersects(&other.resourceReads) ||
+		a.resourceReads.Intersects(&other.resourceWrites)
+}
+
+// AccessDeclarer : 参照、更新するComponentを宣言するSystem
+// 宣言していないSystemは全てのSystemと競合するものとして扱います
+type AccessDeclarer interface {
+	Access() Access
+}
@@ -0,0 +1,91 @@
+package ecsbit
+
+import "reflect"
+
+// Get : Entityが持つComponentのポインタを取得します
+// ComponentはNewComponent[T]で生成し、Worldに登録された型から解決されます.
+// 死んでいるEntityを指定した場合はErrDeadEntityOperation、Componentを持っていない場合はErrMissingComponentでpanicします
+func Get[T any](w *World, e Entity) *T {
+	p, err := TryGet[T](w, e)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// TryGet : Getのエラーを返すバージョンです
+func TryGet[T any](w *World, e Entity) (*T, error) {
+	id, err := componentIDOf[T](w)
+	if err != nil {
+		return nil, err
+	}
+	p, err := w.get(e, id)
+	if err != nil {
+		return nil, err
+	}
+	return (*T)(p), nil
+}
+
+// Set : Entityが持つComponentに値を設定します
+// 設定後に、Componentに登録されたOnSetのコールバックを呼び出します. panicする条件はGetと同じです
+func Set[T any](w *World, e Entity, v T) {
+	if err := TrySet(w, e, v); err != nil {
+		panic(err)
+	}
+}
+
+// TrySet : Setのエラーを返すバージョンです
+func TrySet[T any](w *World, e Entity, v T) error {
+	id, err := componentIDOf[T](w)
+	if err != nil {
+		return err
+	}
+	p, err := w.get(e, id)
+	if err != nil {
+		return err
+	}
+	*(*T)(p) = v
+	w.markChanged(e, id)
+	w.fireOnSet(e, id)
+	return nil
+}
+
+// GetMut : 変更する目的でEntityが持つComponentのポインタを取得します
+// Getと異なり、Componentが現在のTickで変更されたものとして記録されます. panicする条件はGetと同じです
+func GetMut[T any](w *World, e Entity) *T {
+	p := Get[T](w, e)
+	id, _ := componentIDOf[T](w)
+	w.markChanged(e, id)
+	return p
+}
+
+// Has : EntityがComponentを持っているかどうかを返します
+// 登録されていない型の場合はfalseを返します. 死んでいるEntityを指定した場合はErrDeadEntityOperationでpanicします
+func Has[T any](w *World, e Entity) bool {
+	ok, err := TryHas[T](w, e)
+	if err != nil {
+		panic(err)
+	}
+	return ok
+}
+
+// TryHas : Hasのエラーを返すバージョンです
+func TryHas[T any](w *World, e Entity) (bool, error) {
+	id, err := componentIDOf[T](w)
+	if err != nil {
+		if !w.Alive(e) {
+			return false, ErrDeadEntityOperation
+		}
+		return false, nil
+	}
+	return w.has(e, id)
+}
+
+// componentIDOf : 型からWorldに登録されているComponentIDを取得します
+func componentIDOf[T any](w *World) (ComponentID, error) {
+	id, ok := w.componentStorage.TypeID(reflect.TypeFor[T]())
+	if !ok {
+		return 0, ErrUnregisteredComponent
+	}
+	return id, nil
+}
@@ -0,0 +1,126 @@
+package ecsbit
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAccessor_GetSetHas(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+	type Unregistered struct{}
+
+	setup := func() (*World, ComponentID, ComponentID) {
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		return w, posID, velID
+	}
+
+	t.Run("set and get", func(t *testing.T) {
+		// arrange
+		w, posID, velID := setup()
+		e1 := w.CreateEntity(posID, velID)
+		e2 := w.CreateEntity(posID)
+
+		// act
+		Set(w, e1, Position{X: 1, Y: 2})
+		Set(w, e2, Position{X: 3, Y: 4})
+		Get[Velocity](w, e1).X = 5
+
+		// assert
+		if got := *Get[Position](w, e1); got != (Position{X: 1, Y: 2}) {
+			t.Errorf("unexpected result: got %v", got)
+		}
+		if got := *Get[Position](w, e2); got != (Position{X: 3, Y: 4}) {
+			t.Errorf("unexpected result: got %v", got)
+		}
+		if got := Get[Velocity](w, e1).X; got != 5 {
+			t.Errorf("unexpected result: got %v, want %v", got, 5)
+		}
+	})
+
+	t.Run("has", func(t *testing.T) {
+		// arrange
+		w, posID, _ := setup()
+		e := w.CreateEntity(posID)
+
+		// act & assert
+		if !Has[Position](w, e) {
+			t.Errorf("expected has Position, but not")
+		}
+		if Has[Velocity](w, e) {
+			t.Errorf("expected not has Velocity, but has")
+		}
+		if Has[Unregistered](w, e) {
+			t.Errorf("expected not has Unregistered, but has")
+		}
+	})
+
+	t.Run("data survives swap remove", func(t *testing.T) {
+		// arrange
+		w, posID, _ := setup()
+		e1 := w.CreateEntity(posID)
+		e2 := w.CreateEntity(posID)
+		Set(w, e2, Position{X: 2})
+
+		// act
+		w.RemoveEntity(e1)
+		e3 := w.CreateEntity(posID)
+
+		// assert
+		if got := Get[Position](w, e2).X; got != 2 {
+			t.Errorf("unexpected result: got %v, want %v", got, 2)
+		}
+		if e3.ID() != e1.ID() {
+			t.Errorf("expected recycled entity id, got %v", e3)
+		}
+		if got := Get[Position](w, e3).X; got != 0 {
+			t.Errorf("unexpected result: got %v, want %v", got, 0)
+		}
+	})
+
+	t.Run("checked variants", func(t *testing.T) {
+		// arrange
+		w, posID, _ := setup()
+		e := w.CreateEntity(posID)
+
+		// act & assert
+		if _, err := TryGet[Velocity](w, e); !errors.Is(err, ErrMissingComponent) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := TryGet[Unregistered](w, e); !errors.Is(err, ErrUnregisteredComponent) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		w.RemoveEntity(e)
+		if err := TrySet(w, e, Position{}); !errors.Is(err, ErrDeadEntityOperation) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := TryHas[Position](w, e); !errors.Is(err, ErrDeadEntityOperation) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("dead entity panic", func(t *testing.T) {
+		// arrange
+		w, posID, _ := setup()
+		e := w.CreateEntity(posID)
+		w.RemoveEntity(e)
+
+		// act & assert
+		defer func() {
+			err := recover()
+			if err == nil {
+				t.Errorf("expected panic, but not occurred")
+			}
+			if !errors.Is(err.(error), ErrDeadEntityOperation) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+		Get[Position](w, e)
+	})
+}
@@ -14,6 +14,7 @@ func newArchetype(
 ) *archetype {
 	return &archetype{
 		id:            id,
+		layoutMask:    data.layoutMask,
 		archetypeData: data,
 	}
 }
@@ -22,10 +23,25 @@ func newArchetype(
 type archetype struct {
 	id         primitive.ArchetypeID // Archetypeを一意に識別するID
 	layoutMask bits.Mask256          // ArchetypeのLayoutを表すビットマスク
+	edges      []archetypeEdge       // ComponentIDをIndexとした遷移先Archetypeのテーブル（初回利用時に確保する）
 
 	*archetypeData // archetypeから生成されたEntityのデータを保持する構造体
 }
 
+// archetypeEdge : Componentの追加、削除によって遷移するArchetypeを保持する構造体
+type archetypeEdge struct {
+	add    *archetype // Componentを追加した場合の遷移先
+	remove *archetype // Componentを削除した場合の遷移先
+}
+
+// Edge : 指定したComponentの遷移先を保持するarchetypeEdgeを取得する
+func (a *archetype) Edge(id ComponentID) *archetypeEdge {
+	if a.edges == nil {
+		a.edges = make([]archetypeEdge, bits.Mask256Max)
+	}
+	return &a.edges[id]
+}
+
 // ID : archetypeを一意に識別するIDを取得する
 func (a *archetype) ID() primitive.ArchetypeID {
 	return a.id
@@ -42,14 +58,33 @@ func (a *archetype) GetEntity(index uint32) Entity {
 }
 
 // Add : ArchetypeにEntityを追加する
-func (a *archetype) Add(e Entity) uint32 {
+// 各Componentのcolumnにもゼロ値の要素を追加し、EntityとIndexを揃える. 追加した要素は指定したTickで追加されたものとして記録する
+func (a *archetype) Add(e Entity, tick uint32) uint32 {
 	a.entities = append(a.entities, e)
+	for i := range a.columns {
+		a.columns[i].Add(tick)
+	}
 	return uint32(len(a.entities) - 1)
 }
 
+// AddN : Archetypeに複数のEntityを追加し、追加した最初のEntityのIndexを返す
+// entitiesと各Componentのcolumnの拡張は一度に行う
+func (a *archetype) AddN(entities []Entity, tick uint32) uint32 {
+	start := uint32(len(a.entities))
+	a.entities = append(a.entities, entities...)
+	for i := range a.columns {
+		a.columns[i].AddN(uint32(len(entities)), tick)
+	}
+	return start
+}
+
 // Remove : Archetypeに属するEntityを削除する
 // 削除Entityと末尾のEntityを入れ替えることで、削除処理を高速化する
 func (a *archetype) Remove(index uint32) bool {
+	for i := range a.columns {
+		a.columns[i].Remove(index)
+	}
+
 	last := len(a.entities) - 1
 	if index == uint32(last) {
 		a.entities = a.entities[:last]
@@ -62,21 +97,57 @@ func (a *archetype) Remove(index uint32) bool {
 	return true
 }
 
+// Clear : Archetypeに属する全てのEntityを削除する
+func (a *archetype) Clear() {
+	a.entities = a.entities[:0]
+	for i := range a.columns {
+		a.columns[i].Clear()
+	}
+}
+
 // newArchetypeData : archetypeDataを生成する
+// componentsにはComponentIDをIndexとしたComponentの一覧（componentStorage.Types）を指定する
 func newArchetypeData(
 	entityCapacity uint32,
 	layout bits.Mask256,
+	components []component,
 ) *archetypeData {
-	return &archetypeData{
-		entities: make([]Entity, 0, entityCapacity),
+	ids := convertToComponentIDs(&layout)
+	columns := make([]column, len(ids))
+	data := &archetypeData{
+		entities:     make([]Entity, 0, entityCapacity),
+		layoutMask:   layout,
+		columns:      columns,
+		componentIDs: ids,
+	}
+	for i, id := range ids {
+		columns[i] = newColumn(components[id].Type(), entityCapacity)
+		data.columnIndices[id] = uint8(i)
 	}
+	return data
 }
 
 // archetypeData : archetypeから生成されたEntityのデータを保持する構造体
 // archetype : archetypeData は 1 : 1 の関係
 type archetypeData struct {
-	entities   []Entity     // Archetypeに属するEntity
-	layoutMask bits.Mask256 // ArchetypeのLayoutを表すビットマスク
+	entities      []Entity               // Archetypeに属するEntity
+	layoutMask    bits.Mask256           // ArchetypeのLayoutを表すビットマスク
+	columns       []column               // layoutMaskに含まれるComponent毎のデータ列（componentIDsと同じ順序）
+	componentIDs  []ComponentID          // layoutMaskに含まれるComponentID（昇順）
+	columnIndices [bits.Mask256Max]uint8 // ComponentIDからcolumnsのIndexを引くためのテーブル
+}
+
+// HasComponent : 指定したComponentを持っているかどうかを返す
+func (d *archetypeData) HasComponent(id ComponentID) bool {
+	return d.layoutMask.Get(uint32(id))
+}
+
+// Column : 指定したComponentのcolumnを取得する. 持っていない場合はnilを返す
+func (d *archetypeData) Column(id ComponentID) *column {
+	if !d.HasComponent(id) {
+		return nil
+	}
+	return &d.columns[d.columnIndices[id]]
 }
 
 // ConvertToComponentIDs : Mask256をComponentIDのスライスに変換する
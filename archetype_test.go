@@ -1,6 +1,7 @@
 package ecsbit
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/atEaE/ecsbit/internal/bits"
@@ -12,7 +13,7 @@ func TestArchetype_Remove(t *testing.T) {
 
 	t.Run("remove entity swap false", func(t *testing.T) {
 		// arrange
-		a := newArchetype(0, newArchetypeData(entityPoolSize, mask))
+		a := newArchetype(0, newArchetypeData(entityPoolSize, mask, nil))
 		a.entities = append(a.entities, NewEntity(0), NewEntity(1), NewEntity(2), NewEntity(3))
 
 		// act
@@ -33,7 +34,7 @@ func TestArchetype_Remove(t *testing.T) {
 
 	t.Run("remove entity swap true(top)", func(t *testing.T) {
 		// arrange
-		a := newArchetype(0, newArchetypeData(entityPoolSize, mask))
+		a := newArchetype(0, newArchetypeData(entityPoolSize, mask, nil))
 		a.entities = append(a.entities, NewEntity(0), NewEntity(1), NewEntity(2), NewEntity(3))
 
 		// act
@@ -54,7 +55,7 @@ func TestArchetype_Remove(t *testing.T) {
 
 	t.Run("remove entity swap true(middle)", func(t *testing.T) {
 		// arrange
-		a := newArchetype(0, newArchetypeData(entityPoolSize, mask))
+		a := newArchetype(0, newArchetypeData(entityPoolSize, mask, nil))
 		a.entities = append(a.entities, NewEntity(0), NewEntity(1), NewEntity(2), NewEntity(3))
 
 		// act
@@ -94,3 +95,67 @@ func TestConvertToComponentIDs(t *testing.T) {
 		}
 	}
 }
+
+func TestArchetype_Columns(t *testing.T) {
+	type Vector2 struct {
+		X, Y float64
+	}
+	type Rotation struct {
+		F float64
+	}
+
+	// setup
+	cs := newComponentStorage(256)
+	posID := cs.ComponentID(NewComponent[Vector2]())
+	rotID := cs.ComponentID(NewComponent[Rotation]())
+	layout := createLayoutMask([]ComponentID{posID, rotID})
+
+	t.Run("columns follow layout", func(t *testing.T) {
+		// arrange
+		a := newArchetype(0, newArchetypeData(4, layout, cs.Types))
+
+		// assert
+		if len(a.columns) != 2 {
+			t.Fatalf("unexpected column count: %d", len(a.columns))
+		}
+		if c := a.Column(posID); c == nil || c.typ != reflect.TypeOf(Vector2{}) {
+			t.Errorf("unexpected column: %v", c)
+		}
+		if c := a.Column(ComponentID(10)); c != nil {
+			t.Errorf("unexpected column: %v", c)
+		}
+	})
+
+	t.Run("add and remove keep columns in lock-step", func(t *testing.T) {
+		// arrange
+		a := newArchetype(0, newArchetypeData(4, layout, cs.Types))
+		for i := 0; i < 4; i++ {
+			index := a.Add(NewEntity(EntityID(i+1)), 1)
+			(*Vector2)(a.Column(posID).Get(index)).X = float64(i + 1)
+			(*Rotation)(a.Column(rotID).Get(index)).F = float64(i + 1)
+		}
+
+		// act
+		swapped := a.Remove(0)
+
+		// assert
+		if !swapped {
+			t.Errorf("unexpected swapped: %v", swapped)
+		}
+		for i := range a.columns {
+			if got := a.columns[i].Len(); got != uint32(a.Count()) {
+				t.Errorf("unexpected column length: got %d, want %d", got, a.Count())
+			}
+		}
+		moved := a.GetEntity(0)
+		if moved.ID() != 4 {
+			t.Errorf("unexpected entity: %v", moved)
+		}
+		if got := (*Vector2)(a.Column(posID).Get(0)).X; got != 4 {
+			t.Errorf("unexpected value: got %v, want %v", got, 4)
+		}
+		if got := (*Rotation)(a.Column(rotID).Get(0)).F; got != 4 {
+			t.Errorf("unexpected value: got %v, want %v", got, 4)
+		}
+	})
+}
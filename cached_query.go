@@ -0,0 +1,67 @@
+package ecsbit
+
+// RegisterQuery : Filterに一致するArchetypeをキャッシュするCachedQueryを登録します
+// 登録後に生成されたArchetypeもWorldが自動的に追加するため、走査の度に全てのArchetypeを判定する必要がありません.
+// 不要になった場合は、Releaseを呼び出して登録を解除してください
+func (w *World) RegisterQuery(f Filter) *CachedQuery {
+	cq := &CachedQuery{
+		world:      w,
+		filter:     f,
+		archetypes: make([]*archetype, 0, len(w.archetypes)),
+		index:      len(w.cachedQueries),
+	}
+	for _, a := range w.archetypes {
+		cq.match(a)
+	}
+	w.cachedQueries = append(w.cachedQueries, cq)
+	return cq
+}
+
+// CachedQuery : Filterに一致するArchetypeをキャッシュしたQuery
+type CachedQuery struct {
+	world      *World
+	filter     Filter
+	archetypes []*archetype // Filterに一致するArchetype
+	index      int          // World.cachedQueries内でのIndex（解除済みの場合は-1）
+}
+
+// Query : キャッシュ済みのArchetypeを走査するQueryを取得します
+func (cq *CachedQuery) Query() Query {
+	return Query{
+		world:          cq.world,
+		filter:         cq.filter,
+		archetypes:     cq.archetypes,
+		archetypeIndex: -1,
+		matched:        true,
+		filtered:       cq.filter.filtered(),
+		sourced:        cq.filter.sourced(),
+	}
+}
+
+// Released : 登録が解除されているかどうかを返します
+func (cq *CachedQuery) Released() bool {
+	return cq.index < 0
+}
+
+// Release : 登録を解除します. 解除後に生成されたArchetypeはキャッシュに追加されません
+func (cq *CachedQuery) Release() {
+	if cq.Released() {
+		return
+	}
+
+	// 末尾のCachedQueryと入れ替えることで、削除処理を高速化する
+	w := cq.world
+	last := len(w.cachedQueries) - 1
+	w.cachedQueries[cq.index] = w.cachedQueries[last]
+	w.cachedQueries[cq.index].index = cq.index
+	w.cachedQueries[last] = nil
+	w.cachedQueries = w.cachedQueries[:last]
+	cq.index = -1
+}
+
+// match : ArchetypeがFilterに一致する場合はキャッシュに追加します
+func (cq *CachedQuery) match(a *archetype) {
+	if cq.filter.Matches(&a.layoutMask) {
+		cq.archetypes = append(cq.archetypes, a)
+	}
+}
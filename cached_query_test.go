@@ -0,0 +1,72 @@
+package ecsbit
+
+import "testing"
+
+func TestWorld_RegisterQuery(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+
+	setup := func() (*World, ComponentID, ComponentID) {
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		return w, posID, velID
+	}
+
+	count := func(q Query) int {
+		n := 0
+		for q.Next() {
+			n++
+		}
+		return n
+	}
+
+	t.Run("tracks new archetypes", func(t *testing.T) {
+		// arrange
+		w, posID, velID := setup()
+		w.CreateEntity(posID)
+		cq := w.RegisterQuery(NewFilter(posID))
+
+		// act
+		w.CreateEntity(posID, velID)
+		w.CreateEntity(velID)
+
+		// assert
+		if got := len(cq.archetypes); got != 2 {
+			t.Errorf("unexpected archetype count: got %v, want %v", got, 2)
+		}
+		if got := count(cq.Query()); got != 2 {
+			t.Errorf("unexpected result: got %v, want %v", got, 2)
+		}
+	})
+
+	t.Run("release", func(t *testing.T) {
+		// arrange
+		w, posID, velID := setup()
+		cq1 := w.RegisterQuery(NewFilter(posID))
+		cq2 := w.RegisterQuery(NewFilter(velID))
+
+		// act
+		cq1.Release()
+		cq1.Release()
+		w.CreateEntity(posID, velID)
+
+		// assert
+		if !cq1.Released() {
+			t.Errorf("expected released, but not")
+		}
+		if len(w.cachedQueries) != 1 || w.cachedQueries[0] != cq2 || cq2.index != 0 {
+			t.Errorf("unexpected cached queries: %v", w.cachedQueries)
+		}
+		if got := len(cq1.archetypes); got != 0 {
+			t.Errorf("unexpected archetype count: got %v, want %v", got, 0)
+		}
+		if got := count(cq2.Query()); got != 1 {
+			t.Errorf("unexpected result: got %v, want %v", got, 1)
+		}
+	})
+}
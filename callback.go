@@ -0,0 +1,118 @@
+package ecsbit
+
+// CallbackHandle : 登録したコールバックを識別するハンドル
+// World.RemoveCallbackに渡すことで、コールバックの登録を解除できます
+type CallbackHandle uint64
+
+// entityCallback : Entityのライフサイクルに合わせて呼び出すコールバック
+type entityCallback struct {
+	handle   CallbackHandle
+	priority int
+	fn       func(w *World, e Entity) // 解除済みの場合はnil
+}
+
+// newEntityCallbacks : entityCallbacksを生成する
+func newEntityCallbacks(capacity uint32) entityCallbacks {
+	return entityCallbacks{
+		callbacks: make([]entityCallback, 0, capacity),
+	}
+}
+
+// entityCallbacks : 優先度順に並んだコールバックの一覧
+// 呼び出し中に登録、解除された場合でも呼び出し中の一覧が破損しないよう、変更は呼び出し終了後に反映する
+type entityCallbacks struct {
+	callbacks   []entityCallback // 優先度の高い順（同じ優先度の場合は登録順）に並んだコールバック
+	pending     []entityCallback // 呼び出し中に登録されたコールバック
+	dispatching int              // 呼び出し中のネストの深さ
+	dirty       bool             // 呼び出し中に解除されたコールバックがあるかどうか
+}
+
+// Push : コールバックを登録する
+func (l *entityCallbacks) Push(cb entityCallback) {
+	if l.dispatching > 0 {
+		l.pending = append(l.pending, cb)
+		return
+	}
+	l.insert(cb)
+}
+
+// insert : 優先度の順序を保つ位置にコールバックを挿入する
+func (l *entityCallbacks) insert(cb entityCallback) {
+	i := len(l.callbacks)
+	for i > 0 && l.callbacks[i-1].priority < cb.priority {
+		i--
+	}
+	l.callbacks = append(l.callbacks, entityCallback{})
+	copy(l.callbacks[i+1:], l.callbacks[i:])
+	l.callbacks[i] = cb
+}
+
+// Remove : コールバックの登録を解除する. 登録されていない場合はfalseを返す
+func (l *entityCallbacks) Remove(handle CallbackHandle) bool {
+	for i := range l.pending {
+		if l.pending[i].handle == handle {
+			l.pending = append(l.pending[:i], l.pending[i+1:]...)
+			return true
+		}
+	}
+	for i := range l.callbacks {
+		if l.callbacks[i].handle != handle || l.callbacks[i].fn == nil {
+			continue
+		}
+		if l.dispatching > 0 {
+			// 呼び出し中はIndexをずらさないよう、印をつけるだけにして呼び出し終了後に取り除く
+			l.callbacks[i].fn = nil
+			l.dirty = true
+		} else {
+			l.callbacks = append(l.callbacks[:i], l.callbacks[i+1:]...)
+		}
+		return true
+	}
+	return false
+}
+
+// Dispatch : 登録されているコールバックを順に呼び出す
+func (l *entityCallbacks) Dispatch(w *World, e Entity) {
+	l.dispatching++
+	for i := 0; i < len(l.callbacks); i++ {
+		if fn := l.callbacks[i].fn; fn != nil {
+			fn(w, e)
+		}
+	}
+	l.dispatching--
+
+	if l.dispatching == 0 {
+		l.flush()
+	}
+}
+
+// flush : 呼び出し中に行われた登録、解除を反映する
+func (l *entityCallbacks) flush() {
+	if l.dirty {
+		alive := l.callbacks[:0]
+		for _, cb := range l.callbacks {
+			if cb.fn != nil {
+				alive = append(alive, cb)
+			}
+		}
+		clear(l.callbacks[len(alive):])
+		l.callbacks = alive
+		l.dirty = false
+	}
+	for _, cb := range l.pending {
+		l.insert(cb)
+	}
+	clear(l.pending)
+	l.pending = l.pending[:0]
+}
+
+// Len : 登録されているコールバックの数を取得する
+func (l *entityCallbacks) Len() int {
+	n := len(l.pending)
+	for i := range l.callbacks {
+		if l.callbacks[i].fn != nil {
+			n++
+		}
+	}
+	return n
+}
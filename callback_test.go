@@ -0,0 +1,158 @@
+package ecsbit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWorld_EntityCallbacks(t *testing.T) {
+	recorder := func(log *[]string, name string) func(w *World, e Entity) {
+		return func(w *World, e Entity) {
+			*log = append(*log, name)
+		}
+	}
+
+	t.Run("priority order", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		log := []string{}
+		w.PushOnCreateCallback(recorder(&log, "default1"))
+		w.PushOnCreateCallbackWithPriority(recorder(&log, "low"), -10)
+		w.PushOnCreateCallbackWithPriority(recorder(&log, "high"), 10)
+		w.PushOnCreateCallback(recorder(&log, "default2"))
+
+		// act
+		w.CreateEntity()
+
+		// assert
+		want := "high,default1,default2,low"
+		if got := strings.Join(log, ","); got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("remove callback", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		log := []string{}
+		h := w.PushOnCreateCallback(recorder(&log, "a"))
+		w.PushOnCreateCallback(recorder(&log, "b"))
+
+		// act
+		removed := w.RemoveCallback(h)
+		removedTwice := w.RemoveCallback(h)
+		w.CreateEntity()
+
+		// assert
+		if !removed || removedTwice {
+			t.Errorf("unexpected result: %v, %v", removed, removedTwice)
+		}
+		if got := strings.Join(log, ","); got != "b" {
+			t.Errorf("unexpected result: got %v, want %v", got, "b")
+		}
+	})
+
+	t.Run("remove and push while dispatching", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		log := []string{}
+		var second CallbackHandle
+		w.PushOnCreateCallback(func(w *World, e Entity) {
+			log = append(log, "first")
+			w.RemoveCallback(second)
+			w.PushOnCreateCallback(recorder(&log, "pushed"))
+		})
+		second = w.PushOnCreateCallback(recorder(&log, "second"))
+		w.PushOnCreateCallback(recorder(&log, "third"))
+
+		// act
+		w.CreateEntity()
+		first := strings.Join(log, ",")
+		log = log[:0]
+		w.RemoveCallback(CallbackHandle(1))
+		w.CreateEntity()
+
+		// assert
+		if first != "first,third" {
+			t.Errorf("unexpected result: got %v, want %v", first, "first,third")
+		}
+		if got := strings.Join(log, ","); got != "third,pushed" {
+			t.Errorf("unexpected result: got %v, want %v", got, "third,pushed")
+		}
+		if got := w.onCreateCallbacks.Len(); got != 2 {
+			t.Errorf("unexpected callback count: got %v, want %v", got, 2)
+		}
+	})
+}
+
+func TestWorld_OnRemoveCallback(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+
+	t.Run("entity is inspectable in callback", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		e := w.CreateEntity(posID)
+		Set(w, e, Position{X: 3})
+		var alive bool
+		var x float64
+		w.PushOnRemoveCallback(func(w *World, removed Entity) {
+			alive = w.Alive(removed)
+			x = Get[Position](w, removed).X
+		})
+
+		// act
+		w.RemoveEntity(e)
+
+		// assert
+		if !alive || x != 3 {
+			t.Errorf("unexpected result: alive %v, x %v", alive, x)
+		}
+		if w.Alive(e) {
+			t.Errorf("expected entity is dead")
+		}
+	})
+
+	t.Run("nested removal is queued", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		parent := w.CreateEntity(posID)
+		child1 := w.CreateEntity(posID)
+		child2 := w.CreateEntity(posID)
+		other := w.CreateEntity(posID)
+		Set(w, other, Position{X: 9})
+		log := []Entity{}
+		w.PushOnRemoveCallback(func(w *World, removed Entity) {
+			log = append(log, removed)
+			if removed == parent {
+				w.RemoveEntity(child1)
+				w.RemoveEntity(child2)
+				w.RemoveEntity(child1)
+				// キューに積まれただけで、まだ生存している
+				if !w.Alive(child1) {
+					t.Errorf("expected queued entity is still alive")
+				}
+			}
+		})
+
+		// act
+		w.RemoveEntity(parent)
+
+		// assert
+		if len(log) != 3 || log[0] != parent || log[1] != child1 || log[2] != child2 {
+			t.Errorf("unexpected result: %v", log)
+		}
+		if w.Alive(child1) || w.Alive(child2) {
+			t.Errorf("expected children are dead")
+		}
+		if got := Get[Position](w, other).X; got != 9 {
+			t.Errorf("unexpected result: got %v, want %v", got, 9)
+		}
+		if got := w.Stats().Entities.Used; got != 1 {
+			t.Errorf("unexpected entity count: got %v, want %v", got, 1)
+		}
+	})
+}
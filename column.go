@@ -0,0 +1,152 @@
+package ecsbit
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// newColumn : columnを生成する
+func newColumn(typ reflect.Type, capacity uint32) column {
+	if capacity == 0 {
+		capacity = 1
+	}
+	data := reflect.MakeSlice(reflect.SliceOf(typ), int(capacity), int(capacity))
+	c := column{
+		typ:      typ,
+		itemSize: typ.Size(),
+		data:     data,
+		pointer:  data.UnsafePointer(),
+		added:    make([]uint32, capacity),
+		changed:  make([]uint32, capacity),
+		len:      0,
+	}
+	if isRelationType(typ) {
+		c.targets = make([]Entity, capacity)
+	}
+	return c
+}
+
+// column : 1種類のComponentのデータを密に保持する列
+// archetypeに属するEntityと同じ順序でデータを保持するため、Indexはarchetype内のEntityのIndexと一致する
+type column struct {
+	typ      reflect.Type   // Componentの型情報
+	itemSize uintptr        // 1要素あたりのサイズ
+	data     reflect.Value  // 実データを保持するslice（len == capで確保し、使用中の要素数はlenで管理する）
+	pointer  unsafe.Pointer // dataの先頭を指すポインタ（Getの度にreflectを経由しないために保持しておく）
+	added    []uint32       // 要素毎の追加されたTick（dataと同じ長さで確保する）
+	changed  []uint32       // 要素毎の最後に変更されたTick（dataと同じ長さで確保する）
+	targets  []Entity       // 要素毎のRelationのTarget（Relationのcolumnのみ、dataと同じ長さで確保する）
+	len      uint32         // 使用中の要素数
+}
+
+// Len : 使用中の要素数を取得する
+func (c *column) Len() uint32 {
+	return c.len
+}
+
+// Get : 指定したIndexの要素を指すポインタを取得する
+// 範囲外のIndexを指定した場合の動作は保証しない（性能重視のためチェックしない）
+func (c *column) Get(index uint32) unsafe.Pointer {
+	return unsafe.Add(c.pointer, uintptr(index)*c.itemSize)
+}
+
+// Add : 末尾にゼロ値の要素を追加し、追加した要素のIndexを返す
+// 追加した要素は、指定したTickで追加、変更されたものとして記録する. RelationのTargetは未設定になる
+func (c *column) Add(tick uint32) uint32 {
+	if c.len == uint32(c.data.Len()) {
+		c.grow(c.len + 1)
+	}
+	c.added[c.len], c.changed[c.len] = tick, tick
+	if c.targets != nil {
+		c.targets[c.len] = noTarget
+	}
+	c.len++
+	return c.len - 1
+}
+
+// AddN : 末尾にゼロ値の要素を指定した数だけ追加し、追加した最初の要素のIndexを返す
+// 必要な分の拡張は一度に行う
+func (c *column) AddN(n uint32, tick uint32) uint32 {
+	start := c.len
+	if c.len+n > uint32(c.data.Len()) {
+		c.grow(c.len + n)
+	}
+	for i := start; i < start+n; i++ {
+		c.added[i], c.changed[i] = tick, tick
+	}
+	if c.targets != nil {
+		clear(c.targets[start : start+n])
+	}
+	c.len += n
+	return start
+}
+
+// MarkChanged : 指定したIndexの要素を、指定したTickで変更されたものとして記録する
+func (c *column) MarkChanged(index uint32, tick uint32) {
+	c.changed[index] = tick
+}
+
+// MarkAllChanged : 使用中の全ての要素を、指定したTickで変更されたものとして記録する
+func (c *column) MarkAllChanged(tick uint32) {
+	for i := range c.changed[:c.len] {
+		c.changed[i] = tick
+	}
+}
+
+// Remove : 指定したIndexの要素を削除する
+// archetype.Removeと同じく、末尾の要素と入れ替えることで削除処理を高速化する
+func (c *column) Remove(index uint32) bool {
+	last := c.len - 1
+	swapped := index != last
+	if swapped {
+		c.data.Index(int(index)).Set(c.data.Index(int(last)))
+		c.added[index], c.changed[index] = c.added[last], c.changed[last]
+		if c.targets != nil {
+			c.targets[index] = c.targets[last]
+		}
+	}
+	// GCが参照を回収できるように、末尾の要素をゼロ値に戻しておく
+	c.data.Index(int(last)).SetZero()
+	c.len--
+	return swapped
+}
+
+// Clear : 全ての要素を削除する
+func (c *column) Clear() {
+	// GCが参照を回収できるように、使用中の要素をゼロ値に戻しておく
+	c.data.Slice(0, int(c.len)).Clear()
+	c.len = 0
+}
+
+// grow : 少なくとも指定した要素数を保持できるようにdataを拡張する
+func (c *column) grow(size uint32) {
+	capacity := uint32(c.data.Len())
+	for capacity < size {
+		capacity *= 2
+	}
+	data := reflect.MakeSlice(c.data.Type(), int(capacity), int(capacity))
+	reflect.Copy(data, c.data)
+	c.data = data
+	c.pointer = data.UnsafePointer()
+	c.added = append(c.added, make([]uint32, int(capacity)-len(c.added))...)
+	c.changed = append(c.changed, make([]uint32, int(capacity)-len(c.changed))...)
+	if c.targets != nil {
+		c.targets = append(c.targets, make([]Entity, int(capacity)-len(c.targets))...)
+	}
+}
+
+// CopyFrom : 別のcolumnの要素を指定したIndexにコピーする
+// Archetype間でEntityを移動する際に利用するため、追加、変更されたTickとRelationのTargetも引き継ぐ
+func (c *column) CopyFrom(index uint32, src *column, srcIndex uint32) {
+	c.data.Index(int(index)).Set(src.data.Index(int(srcIndex)))
+	c.added[index], c.changed[index] = src.added[srcIndex], src.changed[srcIndex]
+	if c.targets != nil {
+		c.targets[index] = src.targets[srcIndex]
+	}
+}
+
+// columnSlice : columnの使用中の要素を型付きのsliceとして取得する
+// 返すsliceはcolumnのデータを直接参照するため、Archetypeの構造が変わると無効になる点に注意してください
+func columnSlice[T any](c *column) []T {
+	return unsafe.Slice((*T)(c.pointer), c.len)
+}
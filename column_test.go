@@ -0,0 +1,78 @@
+package ecsbit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestColumn_AddAndRemove(t *testing.T) {
+	type Vector2 struct {
+		X, Y float64
+	}
+	typ := reflect.TypeOf(Vector2{})
+
+	t.Run("add grows capacity", func(t *testing.T) {
+		// arrange
+		c := newColumn(typ, 1)
+
+		// act
+		for i := 0; i < 5; i++ {
+			index := c.Add(1)
+			(*Vector2)(c.Get(index)).X = float64(i)
+		}
+
+		// assert
+		if c.Len() != 5 {
+			t.Errorf("unexpected length: %d", c.Len())
+		}
+		for i := uint32(0); i < c.Len(); i++ {
+			if got := (*Vector2)(c.Get(i)).X; got != float64(i) {
+				t.Errorf("unexpected value: got %v, want %v", got, i)
+			}
+		}
+	})
+
+	t.Run("remove swaps last element", func(t *testing.T) {
+		// arrange
+		c := newColumn(typ, 4)
+		for i := 0; i < 4; i++ {
+			(*Vector2)(c.Get(c.Add(1))).X = float64(i)
+		}
+
+		// act
+		swapped := c.Remove(1)
+
+		// assert
+		if !swapped {
+			t.Errorf("unexpected swapped: %v", swapped)
+		}
+		if c.Len() != 3 {
+			t.Errorf("unexpected length: %d", c.Len())
+		}
+		if got := (*Vector2)(c.Get(1)).X; got != 3 {
+			t.Errorf("unexpected value: got %v, want %v", got, 3)
+		}
+		// 削除後の末尾はゼロ値に戻っていること
+		if got := (*Vector2)(c.Get(3)).X; got != 0 {
+			t.Errorf("unexpected value: got %v, want %v", got, 0)
+		}
+	})
+
+	t.Run("zero size type", func(t *testing.T) {
+		// arrange
+		c := newColumn(reflect.TypeOf(struct{}{}), 0)
+
+		// act
+		c.Add(1)
+		c.Add(1)
+		swapped := c.Remove(0)
+
+		// assert
+		if !swapped {
+			t.Errorf("unexpected swapped: %v", swapped)
+		}
+		if c.Len() != 1 {
+			t.Errorf("unexpected length: %d", c.Len())
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package ecsbit
+
+import "sync"
+
+// commandKind : CommandBufferに記録する操作の種類
+type commandKind uint8
+
+const (
+	commandCreateEntity    commandKind = iota // Entityの生成
+	commandRemoveEntity                       // Entityの削除
+	commandAddComponent                       // Componentの追加
+	commandRemoveComponent                    // Componentの削除
+	commandSet                                // Componentへの値の設定
+)
+
+// command : CommandBufferに記録された1件の操作
+type command struct {
+	kind       commandKind
+	entity     Entity
+	components []ComponentID
+	set        func(w *World, e Entity) // commandSetの場合に値を設定する関数
+}
+
+// NewCommandBuffer : CommandBufferを生成します
+func NewCommandBuffer(w *World) *CommandBuffer {
+	return &CommandBuffer{
+		world:    w,
+		commands: make([]command, 0, 64),
+	}
+}
+
+// CommandBuffer : Worldの構造を変更する操作を記録し、後からまとめて適用するためのバッファ
+// Queryの走査中にEntityの生成、削除やComponentの追加、削除を行うとArchetypeの走査が破損するため、
+// 走査中はCommandBufferに記録しておき、走査後にWorld.Applyで適用してください.
+// 複数のgoroutineから同時に記録することができます
+type CommandBuffer struct {
+	world    *World
+	mu       sync.Mutex
+	commands []command // 記録中の操作
+	spare    []command // 適用中に記録された操作を受け付けるため、commandsと入れ替えて利用する予備のslice
+}
+
+// Len : 記録されている操作の数を取得します
+func (cb *CommandBuffer) Len() int {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return len(cb.commands)
+}
+
+// CreateEntity : Entityの生成を記録します
+// 返却するEntityは予約済みのもので、適用するまでは生存していない扱いですが、以降の操作の記録に利用できます.
+// 適用後はそのまま生成されたEntityとして利用できます
+func (cb *CommandBuffer) CreateEntity(components ...ComponentID) Entity {
+	e := cb.world.entityPool.Reserve()
+	cb.push(command{kind: commandCreateEntity, entity: e, components: components})
+	return e
+}
+
+// RemoveEntity : Entityの削除を記録します
+func (cb *CommandBuffer) RemoveEntity(e Entity) {
+	cb.push(command{kind: commandRemoveEntity, entity: e})
+}
+
+// AddComponent : Componentの追加を記録します
+func (cb *CommandBuffer) AddComponent(e Entity, components ...ComponentID) {
+	cb.push(command{kind: commandAddComponent, entity: e, components: components})
+}
+
+// RemoveComponent : Componentの削除を記録します
+func (cb *CommandBuffer) RemoveComponent(e Entity, components ...ComponentID) {
+	cb.push(command{kind: commandRemoveComponent, entity: e, components: components})
+}
+
+// DeferSet : Componentへの値の設定をCommandBufferに記録します
+func DeferSet[T any](cb *CommandBuffer, e Entity, v T) {
+	cb.push(command{
+		kind:   commandSet,
+		entity: e,
+		set: func(w *World, e Entity) {
+			Set(w, e, v)
+		},
+	})
+}
+
+// Reset : 記録されている操作を破棄します
+// 予約済みのEntityは解放されます
+func (cb *CommandBuffer) Reset() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	w := cb.world
+	for i := range cb.commands {
+		if cb.commands[i].kind == commandCreateEntity {
+			w.entityPool.FlushReserved()
+			w.entityPool.Recycle(cb.commands[i].entity)
+		}
+	}
+	cb.clear()
+}
+
+// push : 操作を記録します
+func (cb *CommandBuffer) push(c command) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.commands = append(cb.commands, c)
+}
+
+// clear : 記録されている操作を破棄します（予約済みのEntityは解放しません）
+func (cb *CommandBuffer) clear() {
+	// 関数やsliceへの参照を解放するため、ゼロ値で埋めてから長さを0にする
+	clear(cb.commands)
+	cb.commands = cb.commands[:0]
+}
+
+// take : 記録されている操作を取り出し、以降の記録を予備のsliceで受け付けます
+func (cb *CommandBuffer) take() []command {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	commands := cb.commands
+	cb.commands, cb.spare = cb.spare, nil
+	return commands
+}
+
+// release : takeで取り出したsliceを予備のsliceとして戻します
+func (cb *CommandBuffer) release(commands []command) {
+	clear(commands)
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.spare = commands[:0]
+}
+
+// Commands : Worldが保持するCommandBufferを取得します
+// Schedulerは各Stageの終了時に、このCommandBufferを適用します
+func (w *World) Commands() *CommandBuffer {
+	return w.commands
+}
+
+// Apply : CommandBufferに記録された操作を記録順に適用し、CommandBufferを空にします
+// 適用時点で生存していないEntityに対する操作は無視されます.
+// 適用中（コールバック内など）に記録された操作は、次回のApplyで適用されます
+func (w *World) Apply(cb *CommandBuffer) {
+	w.checkLocked()
+	commands := cb.take()
+	defer cb.release(commands)
+
+	w.entityPool.FlushReserved()
+	for i := range commands {
+		c := &commands[i]
+		if c.kind == commandCreateEntity {
+			w.spawn(c.entity, w.findOrCreateArchetype(c.components))
+			continue
+		}
+
+		if !w.Alive(c.entity) {
+			continue
+		}
+		switch c.kind {
+		case commandRemoveEntity:
+			w.RemoveEntity(c.entity)
+		case commandAddComponent:
+			w.AddComponent(c.entity, c.components...)
+		case commandRemoveComponent:
+			w.RemoveComponent(c.entity, c.components...)
+		case commandSet:
+			c.set(w, c.entity)
+		}
+	}
+}
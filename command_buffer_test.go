@@ -0,0 +1,147 @@
+package ecsbit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCommandBuffer_Apply(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+
+	setup := func() (*World, ComponentID, ComponentID) {
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		return w, posID, velID
+	}
+
+	t.Run("reserved entity", func(t *testing.T) {
+		// arrange
+		w, posID, velID := setup()
+		cb := NewCommandBuffer(w)
+
+		// act
+		e := cb.CreateEntity(posID)
+		DeferSet(cb, e, Position{X: 1})
+		cb.AddComponent(e, velID)
+		DeferSet(cb, e, Velocity{X: 2})
+		aliveBeforeApply := w.Alive(e)
+		other := w.CreateEntity(posID) // 予約済みのEntityIDとは重複しないこと
+		w.Apply(cb)
+
+		// assert
+		if aliveBeforeApply {
+			t.Errorf("expected reserved entity is not alive before apply")
+		}
+		if e == other {
+			t.Errorf("unexpected duplicated entity: %v", e)
+		}
+		if !w.Alive(e) {
+			t.Fatalf("expected entity is alive after apply")
+		}
+		if got := Get[Position](w, e).X; got != 1 {
+			t.Errorf("unexpected result: got %v, want %v", got, 1)
+		}
+		if got := Get[Velocity](w, e).X; got != 2 {
+			t.Errorf("unexpected result: got %v, want %v", got, 2)
+		}
+		if cb.Len() != 0 {
+			t.Errorf("unexpected command count: %d", cb.Len())
+		}
+	})
+
+	t.Run("structural changes during iteration", func(t *testing.T) {
+		// arrange
+		w, posID, velID := setup()
+		for i := 0; i < 10; i++ {
+			w.CreateEntity(posID)
+		}
+		cb := NewCommandBuffer(w)
+
+		// act
+		q := w.Query(NewFilter(posID))
+		for q.Next() {
+			if q.Entity().ID()%2 == 0 {
+				cb.RemoveEntity(q.Entity())
+			} else {
+				cb.AddComponent(q.Entity(), velID)
+			}
+		}
+		w.Apply(cb)
+
+		// assert
+		if got := w.Stats().Entities.Used; got != 5 {
+			t.Errorf("unexpected entity count: got %v, want %v", got, 5)
+		}
+		q = w.Query(NewFilter(posID, velID))
+		if got := q.Count(); got != 5 {
+			t.Errorf("unexpected result: got %v, want %v", got, 5)
+		}
+	})
+
+	t.Run("dead entity commands are ignored", func(t *testing.T) {
+		// arrange
+		w, posID, _ := setup()
+		e := w.CreateEntity(posID)
+		cb := NewCommandBuffer(w)
+
+		// act
+		cb.RemoveEntity(e)
+		cb.RemoveEntity(e)
+		DeferSet(cb, e, Position{})
+		w.Apply(cb)
+
+		// assert
+		if w.Alive(e) {
+			t.Errorf("expected entity is dead")
+		}
+	})
+
+	t.Run("reset releases reserved entity", func(t *testing.T) {
+		// arrange
+		w, posID, _ := setup()
+		cb := NewCommandBuffer(w)
+		e := cb.CreateEntity(posID)
+
+		// act
+		cb.Reset()
+		w.Apply(cb)
+
+		// assert
+		if w.Alive(e) {
+			t.Errorf("expected entity is dead")
+		}
+		if got := w.Stats().Entities.Recycled; got != 1 {
+			t.Errorf("unexpected recycled count: got %v, want %v", got, 1)
+		}
+	})
+
+	t.Run("scheduler applies at stage boundary", func(t *testing.T) {
+		// arrange
+		w, posID, _ := setup()
+		var spawned Entity
+		aliveInUpdate := true
+		s := NewScheduler()
+		_ = s.AddSystem(StagePreUpdate, "spawn", SystemFunc(func(w *World, dt time.Duration) {
+			spawned = w.Commands().CreateEntity(posID)
+		}))
+		_ = s.AddSystem(StageUpdate, "check", SystemFunc(func(w *World, dt time.Duration) {
+			aliveInUpdate = w.Alive(spawned)
+		}))
+
+		// act
+		if err := s.Update(w, time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// assert
+		if !aliveInUpdate {
+			t.Errorf("expected entity is alive in next stage")
+		}
+	})
+}
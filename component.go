@@ -55,6 +55,7 @@ func newComponentStorage(maxSize uint32) componentStorage {
 		Components: make(map[component]ComponentID, maxSizeInt),
 		Types:      make([]component, maxSize),
 		IDs:        make([]ComponentID, 0, maxSize),
+		TypeIDs:    make(map[reflect.Type]ComponentID, maxSizeInt),
 
 		maxSize: int(maxSize),
 	}
@@ -65,6 +66,8 @@ type componentStorage struct {
 	Components map[component]ComponentID
 	Types      []component
 	IDs        []ComponentID
+	TypeIDs    map[reflect.Type]ComponentID // 型からComponentIDを引くためのMap（同じ型が複数登録された場合は最初に登録されたもの）
+	Relations  bits.Mask256                 // Relationとして登録されたComponent
 
 	maxSize int
 }
@@ -86,5 +89,27 @@ func (s *componentStorage) register(c component) ComponentID {
 	newID := ComponentID(idInt)
 	s.Components[c], s.Types[newID] = newID, c
 	s.IDs = append(s.IDs, newID)
+	if _, ok := s.TypeIDs[c.typ]; !ok {
+		s.TypeIDs[c.typ] = newID
+	}
+	if isRelationType(c.typ) {
+		s.Relations.Set(uint32(newID), true)
+	}
 	return newID
 }
+
+// Registered : 指定したComponentIDが登録済みかどうかを返す
+func (s *componentStorage) Registered(id ComponentID) bool {
+	return int(id) < len(s.IDs)
+}
+
+// IsRelation : 指定したComponentがRelationかどうかを返す
+func (s *componentStorage) IsRelation(id ComponentID) bool {
+	return s.Relations.Get(uint32(id))
+}
+
+// TypeID : 型からComponentIDを取得する. 登録されていない場合はfalseを返す
+func (s *componentStorage) TypeID(typ reflect.Type) (ComponentID, bool) {
+	id, ok := s.TypeIDs[typ]
+	return id, ok
+}
@@ -10,6 +10,8 @@ var defaultConfig = config.WorldConfig{
 	EntityPoolDefaultCapacity:        1024,
 	OnCreateCallbacksDefaultCapacity: 256,
 	OnRemoveCallbacksDefaultCapacity: 256,
+	IterationGuard:                   true,
+	CascadeRemove:                    false,
 }
 
 // Default : Worldのデフォルトオプションを取得する
@@ -47,3 +49,19 @@ func WithOnRemoveCallbacksDefaultCapacity(capacity uint32) WorldConfigOption {
 		c.OnRemoveCallbacksDefaultCapacity = capacity
 	}
 }
+
+// WithIterationGuard : Queryの走査中にWorldの構造を変更した場合にpanicさせるかどうかを設定する
+// 走査の開始と終了でカウンタを更新するだけなので、基本的には有効なままの利用を想定している
+func WithIterationGuard(enabled bool) WorldConfigOption {
+	return func(c *config.WorldConfig) {
+		c.IterationGuard = enabled
+	}
+}
+
+// WithCascadeRemove : Entityを削除した際に、子孫のEntityも再帰的に削除するかどうかを設定する
+// 無効の場合、削除されたEntityの子は親が未設定の状態になる
+func WithCascadeRemove(enabled bool) WorldConfigOption {
+	return func(c *config.WorldConfig) {
+		c.CascadeRemove = enabled
+	}
+}
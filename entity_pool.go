@@ -1,6 +1,10 @@
 package ecsbit
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"sync/atomic"
+)
 
 var (
 	ErrRecycleSentinel = fmt.Errorf("can't recycle reserved entity")
@@ -24,6 +28,7 @@ type entityPool struct {
 	entities  []Entity // 使用中の生きているEntityと死んでいるEntityが一緒に入っている点に注意してください.
 	next      EntityID // 次に利用するEntityID (RecycleされたEntityIDを再利用するために利用する)
 	available uint32   // 利用可能なEntityの数
+	reserved  uint32   // 予約済みで、まだentitiesに追加されていないEntityの数（Reserveから並行して更新されるためatomicに扱う）
 }
 
 // Get : Entity PoolからEntityを取得します
@@ -41,12 +46,54 @@ func (p *entityPool) Get() Entity {
 	return p.entities[recycledID]
 }
 
+// GetN : Entity PoolからEntityを取得し、指定したsliceを埋めます
+// RecycleされたEntityを優先して利用し、足りない分はentitiesを一度に拡張して新たに作り出します
+func (p *entityPool) GetN(dst []Entity) {
+	i := 0
+	for ; i < len(dst) && p.available > 0; i++ {
+		dst[i] = p.Get()
+	}
+	if i == len(dst) {
+		return
+	}
+
+	p.FlushReserved()
+	p.entities = slices.Grow(p.entities, len(dst)-i)
+	for ; i < len(dst); i++ {
+		e := NewEntity(EntityID(len(p.entities)))
+		p.entities = append(p.entities, e)
+		dst[i] = e
+	}
+}
+
 func (p *entityPool) new() Entity {
+	p.FlushReserved()
 	e := NewEntity(EntityID(len(p.entities)))
 	p.entities = append(p.entities, e)
 	return e
 }
 
+// Reserve : 新しいEntityIDを予約します
+// entitiesは更新しないため、複数のgoroutineから呼び出すことができます（Get, Recycleとは同時に呼び出せません）.
+// 予約したEntityはFlushReservedを呼び出すまでAliveがfalseを返します
+func (p *entityPool) Reserve() Entity {
+	n := atomic.AddUint32(&p.reserved, 1)
+	return NewEntity(EntityID(len(p.entities) + int(n) - 1))
+}
+
+// FlushReserved : 予約済みのEntityをentitiesに追加し、生存している状態にします
+func (p *entityPool) FlushReserved() {
+	n := atomic.SwapUint32(&p.reserved, 0)
+	for i := uint32(0); i < n; i++ {
+		p.entities = append(p.entities, NewEntity(EntityID(len(p.entities))))
+	}
+}
+
+// Reserved : 予約済みで、まだentitiesに追加されていないEntityの数を返します
+func (p *entityPool) Reserved() int {
+	return int(atomic.LoadUint32(&p.reserved))
+}
+
 // Recycle : 指定したEntityをリサイクル可能な状態にする
 // この関数に渡したEntityは、その時点で無効な状態になります.
 // この関数を呼び出した後に、Alive関数を呼び出すとfalseが返ります.
@@ -78,6 +125,10 @@ func (p *entityPool) IsRecycleWait(eid EntityID) bool {
 // Alive : 該当のEntityが生存しているかどうかを返します
 func (p *entityPool) Alive(e Entity) bool {
 	// NOTE: versionが異なる場合は、リサイクル済みでEntityとしてはすでに死んでいるためfalseを返す
+	// 予約済みでまだentitiesに追加されていないEntityも生存していない扱いにする
+	if int(e.ID()) >= len(p.entities) {
+		return false
+	}
 	return e.Version() == p.entities[e.ID()].Version()
 }
 
@@ -125,3 +125,30 @@ func TestEntityPool_RecycleAndGet(t *testing.T) {
 		pool.Recycle(e)
 	})
 }
+
+func TestEntityPool_Reserve(t *testing.T) {
+	// arrange
+	pool := newEntityPool(10)
+	created := pool.Get()
+
+	// act
+	r1 := pool.Reserve()
+	r2 := pool.Reserve()
+
+	// assert
+	if r1.ID() != created.ID()+1 || r2.ID() != created.ID()+2 {
+		t.Errorf("unexpected reserved entity: %v, %v", r1, r2)
+	}
+	if pool.Alive(r1) || pool.Alive(r2) {
+		t.Errorf("expected reserved entity is not alive before flush")
+	}
+
+	// 新しいEntityを生成する場合は、予約済みのEntityIDを避けること
+	e := pool.Get()
+	if e.ID() != r2.ID()+1 {
+		t.Errorf("unexpected entity id: %d", e.ID())
+	}
+	if !pool.Alive(r1) || !pool.Alive(r2) {
+		t.Errorf("expected reserved entity is alive after flush")
+	}
+}
@@ -7,4 +7,34 @@ var (
 	ErrDeadEntityOperation = fmt.Errorf("can't operate a dead entity")
 	// ErrDuplicateComponent : 重複したComponentを一緒にEntityに対して追加しようとした場合に発生するエラー
 	ErrDuplicateComponent = fmt.Errorf("duplicate components")
+	// ErrUnregisteredComponent : Worldに登録されていないComponentを操作しようとした場合に発生するエラー
+	ErrUnregisteredComponent = fmt.Errorf("unregistered component")
+	// ErrMissingComponent : Entityが持っていないComponentを操作しようとした場合に発生するエラー
+	ErrMissingComponent = fmt.Errorf("entity does not have the component")
+	// ErrWorldLocked : Queryの走査中にWorldの構造を変更しようとした場合に発生するエラー
+	ErrWorldLocked = fmt.Errorf("can't change world structure while a query is iterating (use CommandBuffer instead)")
+	// ErrNotRelation : Relationではないcomponentに対してRelationの操作をしようとした場合に発生するエラー
+	ErrNotRelation = fmt.Errorf("component is not a relation")
+	// ErrHierarchyCycle : 親子関係が循環するような親を設定しようとした場合に発生するエラー
+	ErrHierarchyCycle = fmt.Errorf("hierarchy can't contain cycles")
+	// ErrInvalidSnapshot : 読み込もうとしたSnapshotの形式が不正な場合に発生するエラー
+	ErrInvalidSnapshot = fmt.Errorf("invalid snapshot")
+	// ErrUnserializableComponent : Snapshotに保存できないComponentを持つEntityがある場合に発生するエラー
+	ErrUnserializableComponent = fmt.Errorf("component can't be serialized")
+	// ErrSnapshotComponentMismatch : Snapshotに含まれるComponentと、読み込み時に指定したComponentが一致しない場合に発生するエラー
+	ErrSnapshotComponentMismatch = fmt.Errorf("snapshot component mismatch")
+	// ErrFilteredChunks : Added, Changedで絞り込んだQueryをChunk単位で走査しようとした場合に発生するエラー
+	ErrFilteredChunks = fmt.Errorf("can't iterate chunks with entity-level filters (use All instead)")
+	// ErrReservedEntities : CommandBufferで予約されたEntityが未適用のままSnapshotを保存しようとした場合に発生するエラー
+	ErrReservedEntities = fmt.Errorf("can't save a snapshot while entities are reserved by a command buffer")
+	// ErrRemovedNotTracked : TrackRemovedで削除を記録していないComponentの記録を参照しようとした場合に発生するエラー
+	ErrRemovedNotTracked = fmt.Errorf("component removal is not tracked")
+	// ErrUnknownStage : 存在しないStageを指定した場合に発生するエラー
+	ErrUnknownStage = fmt.Errorf("unknown stage")
+	// ErrDuplicateSystem : 同じ名前のSystemを登録しようとした場合に発生するエラー
+	ErrDuplicateSystem = fmt.Errorf("duplicate system")
+	// ErrUnknownSystem : 登録されていないSystemを順序指定に利用した場合に発生するエラー
+	ErrUnknownSystem = fmt.Errorf("unknown system")
+	// ErrSystemCycle : Systemの実行順の指定に循環がある場合に発生するエラー
+	ErrSystemCycle = fmt.Errorf("system order has a cycle")
 )
@@ -0,0 +1,159 @@
+package ecsbit
+
+import (
+	"slices"
+
+	"github.com/atEaE/ecsbit/internal/bits"
+)
+
+// NewFilter : 指定したComponentを全て持つEntityを対象とするFilterを生成します
+func NewFilter(required ...ComponentID) Filter {
+	return Filter{
+		required: createLayoutMask(required),
+	}
+}
+
+// Filter : Queryの対象となるArchetypeを絞り込むための条件
+// 条件はArchetypeのLayoutMaskに対するビット演算で判定します
+type Filter struct {
+	required bits.Mask256 // 全て持っている必要があるComponent
+	excluded bits.Mask256 // 1つも持っていてはいけないComponent
+	optional bits.Mask256 // 持っていなくても良いが、Queryから参照する可能性のあるComponent
+
+	relations []relationCondition // RelationのTargetの条件（Entity単位で判定する）
+
+	added   bits.Mask256 // sinceより後に追加されている必要があるComponent
+	changed bits.Mask256 // sinceより後に変更されている必要があるComponent
+	since   uint32       // Added, Changedの判定の基準になるTick
+}
+
+// With : 持っている必要があるComponentを追加します
+func (f Filter) With(ids ...ComponentID) Filter {
+	for _, id := range ids {
+		f.required.Set(uint32(id), true)
+	}
+	return f
+}
+
+// Without : 持っていてはいけないComponentを追加します
+func (f Filter) Without(ids ...ComponentID) Filter {
+	for _, id := range ids {
+		f.excluded.Set(uint32(id), true)
+	}
+	return f
+}
+
+// Optional : 持っていなくても良いComponentを追加します
+// 判定には影響しませんが、Query内でComponentを参照する意図を明示するために利用します
+func (f Filter) Optional(ids ...ComponentID) Filter {
+	for _, id := range ids {
+		f.optional.Set(uint32(id), true)
+	}
+	return f
+}
+
+// WithRelation : 指定したRelationを持ち、そのTargetが一致するEntityに絞り込みます
+// 1つのEntityはRelationの種類毎にTargetを1つだけ持てるため、同じRelationに異なるTargetを重ねて指定すると、一致するEntityはありません.
+// 走査はTargetを設定しているEntityの逆引きから行い、異なるRelationの条件を重ねて指定した場合は、最も少ないものから走査します.
+// Targetを問わずRelationを持つEntityを対象にする場合は、Withを利用してください
+func (f Filter) WithRelation(relation ComponentID, target Entity) Filter {
+	f.required.Set(uint32(relation), true)
+	// コピー元のFilterと配列を共有しないように、容量を切り詰めてから追加する
+	f.relations = append(slices.Clip(f.relations), relationCondition{relation: relation, target: target})
+	return f
+}
+
+// Added : 持っている必要があり、かつSinceで指定したTickより後に追加されたComponentを追加します
+// 判定はEntity単位で行うため、条件を満たさないEntityは走査時に読み飛ばされます
+func (f Filter) Added(ids ...ComponentID) Filter {
+	for _, id := range ids {
+		f.required.Set(uint32(id), true)
+		f.added.Set(uint32(id), true)
+	}
+	return f
+}
+
+// Changed : 持っている必要があり、かつSinceで指定したTickより後に変更されたComponentを追加します
+// 追加されたComponentも変更されたものとして扱います. 判定はEntity単位で行います
+func (f Filter) Changed(ids ...ComponentID) Filter {
+	for _, id := range ids {
+		f.required.Set(uint32(id), true)
+		f.changed.Set(uint32(id), true)
+	}
+	return f
+}
+
+// Since : Added, Changedの判定の基準になるTickを設定します
+// 通常はSystemTicks.Updateが返す、Systemが前回実行された時点のTickを指定します
+func (f Filter) Since(tick uint32) Filter {
+	f.since = tick
+	return f
+}
+
+// Added : 型からComponentIDを解決し、Filter.Addedと同じ条件を追加したFilterを返します
+// 型がWorldに登録されていない場合はErrUnregisteredComponentでpanicします
+//
+//	f := ecsbit.Added[Position](w, ecsbit.NewFilter()).Since(since)
+func Added[T any](w *World, f Filter) Filter {
+	return f.Added(NewField[T](w).ID())
+}
+
+// Changed : 型からComponentIDを解決し、Filter.Changedと同じ条件を追加したFilterを返します
+// 型がWorldに登録されていない場合はErrUnregisteredComponentでpanicします
+func Changed[T any](w *World, f Filter) Filter {
+	return f.Changed(NewField[T](w).ID())
+}
+
+// Matches : 指定したLayoutMaskがFilterの条件を満たすかどうかを返します
+func (f *Filter) Matches(layout *bits.Mask256) bool {
+	return layout.Contains(&f.required) && !layout.Intersects(&f.excluded)
+}
+
+// matchesTargets : 指定したArchetypeのIndexの位置にあるEntityが、WithRelationの条件を満たすかどうかを返します
+func (f *Filter) matchesTargets(a *archetype, index uint32) bool {
+	for _, r := range f.relations {
+		c := a.Column(r.relation)
+		if c == nil || c.targets[index] != r.target {
+			return false
+		}
+	}
+	return true
+}
+
+// filtered : Added, Changed, WithRelationによるEntity単位の判定が必要かどうかを返します
+func (f *Filter) filtered() bool {
+	return len(f.relations) > 0 || !f.added.IsZero() || !f.changed.IsZero()
+}
+
+// sourced : WithRelationのTargetを設定しているEntityの逆引きから走査できるかどうかを返します
+// 未設定のTargetは逆引きに保持されないため、Targetが未設定の条件しかない場合はArchetypeから走査します
+func (f *Filter) sourced() bool {
+	for _, r := range f.relations {
+		if r.target != noTarget {
+			return true
+		}
+	}
+	return false
+}
+
+// relationSources : WithRelationの条件のうち、Targetを設定しているEntityが最も少ないものの逆引きを取得します
+func (f *Filter) relationSources(w *World) []Entity {
+	var sources []Entity
+	found := false
+	for _, r := range f.relations {
+		if r.target == noTarget {
+			continue
+		}
+		s := w.relationSources[relationKey{relation: r.relation, target: r.target}]
+		if !found || len(s) < len(sources) {
+			sources, found = s, true
+		}
+	}
+	return sources
+}
+
+// relationCondition : WithRelationで指定したRelationとTargetの組
+type relationCondition struct {
+	relation ComponentID
+	target   Entity
+}
@@ -0,0 +1,133 @@
+package ecsbit
+
+import (
+	"iter"
+	"reflect"
+)
+
+// childOfType : ChildOfの型情報
+var childOfType = reflect.TypeFor[ChildOf]()
+
+// ChildOf : 親子関係を表すRelation
+// SetParentを初めて呼び出した際に自動で登録されます.
+// Queryで親子関係を絞り込む場合は、事前にRegisterComponentで登録してComponentIDを取得してください
+//
+//	childOfID := w.RegisterComponent(ecsbit.NewComponent[ecsbit.ChildOf]())
+//	q := w.Query(ecsbit.NewFilter().WithRelation(childOfID, parent))
+type ChildOf struct {
+	Relation
+}
+
+// SetParent : Entityの親を設定します
+// ChildOfを持っていない場合は追加します. ChildOf以外のRelationを持つEntityにも設定できます.
+// 親にゼロ値のEntityを指定すると、親が未設定の状態になります.
+// 自分自身や子孫を親に指定した場合はErrHierarchyCycleでpanicします
+func (w *World) SetParent(child, parent Entity) {
+	w.checkLocked()
+	if !w.Alive(child) {
+		panic(ErrDeadEntityOperation)
+	}
+	if parent != noTarget {
+		if !w.Alive(parent) {
+			panic(ErrDeadEntityOperation)
+		}
+		// 親を辿って自分自身が現れる場合は循環する
+		for p := parent; p != noTarget; p = w.Parent(p) {
+			if p == child {
+				panic(ErrHierarchyCycle)
+			}
+		}
+	}
+
+	childOfID := w.childOfID()
+	if !w.entityIndices[child.ID()].archetype.HasComponent(childOfID) {
+		if parent == noTarget {
+			return
+		}
+		w.AddComponent(child, childOfID)
+		// OnAddやObserverのコールバック内で削除、変更されている可能性があるので確認し直す
+		if !w.Alive(child) || !w.Alive(parent) || !w.entityIndices[child.ID()].archetype.HasComponent(childOfID) {
+			return
+		}
+	}
+	w.setTarget(child, &w.entityIndices[child.ID()], childOfID, parent)
+}
+
+// Parent : Entityの親を取得します. 親が未設定の場合はゼロ値のEntityを返します
+func (w *World) Parent(e Entity) Entity {
+	index, err := w.entityIndex(e)
+	if err != nil {
+		panic(err)
+	}
+	childOfID, ok := w.componentStorage.TypeID(childOfType)
+	if !ok {
+		return noTarget
+	}
+	c := index.archetype.Column(childOfID)
+	if c == nil {
+		return noTarget
+	}
+	return c.targets[index.index]
+}
+
+// Children : Entityの子を走査します
+// 走査中はWorldがロックされるため、Worldの構造を変更する場合は走査を終えてから行ってください
+func (w *World) Children(e Entity) iter.Seq[Entity] {
+	return func(yield func(Entity) bool) {
+		w.lock()
+		defer w.unlock()
+		w.yieldChildren(e, yield)
+	}
+}
+
+// Descendants : Entityの子孫を深さ優先（行きがけ順）で走査します
+// 走査中はWorldがロックされるため、Worldの構造を変更する場合は走査を終えてから行ってください
+func (w *World) Descendants(e Entity) iter.Seq[Entity] {
+	return func(yield func(Entity) bool) {
+		w.lock()
+		defer w.unlock()
+
+		var walk func(parent Entity) bool
+		walk = func(parent Entity) bool {
+			return w.yieldChildren(parent, func(child Entity) bool {
+				return yield(child) && walk(child)
+			})
+		}
+		walk(e)
+	}
+}
+
+// yieldChildren : Entityの子を順に渡します. yieldがfalseを返した場合は中断してfalseを返します
+func (w *World) yieldChildren(parent Entity, yield func(Entity) bool) bool {
+	childOfID, ok := w.componentStorage.TypeID(childOfType)
+	if !ok {
+		return true
+	}
+	for _, child := range w.relationSources[relationKey{relation: childOfID, target: parent}] {
+		if !yield(child) {
+			return false
+		}
+	}
+	return true
+}
+
+// removeChildren : Entityの子を再帰的に削除します
+func (w *World) removeChildren(parent Entity) {
+	childOfID, ok := w.componentStorage.TypeID(childOfType)
+	if !ok {
+		return
+	}
+	key := relationKey{relation: childOfID, target: parent}
+	// 子を削除する度に逆引きから取り除かれるので、末尾から順に削除する
+	for children := w.relationSources[key]; len(children) > 0; children = w.relationSources[key] {
+		w.removeEntity(children[len(children)-1])
+	}
+}
+
+// childOfID : ChildOfのComponentIDを取得します. 未登録の場合は登録します
+func (w *World) childOfID() ComponentID {
+	if id, ok := w.componentStorage.TypeID(childOfType); ok {
+		return id
+	}
+	return w.RegisterComponent(NewComponent[ChildOf]())
+}
@@ -0,0 +1,190 @@
+package ecsbit
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/atEaE/ecsbit/config"
+)
+
+func TestWorld_Hierarchy(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+
+	// root
+	// ├── a
+	// │   └── a1
+	// └── b
+	setup := func(opts ...config.WorldConfigOption) (w *World, root, a, a1, b Entity) {
+		w = NewWorld(opts...)
+		posID := w.RegisterComponent(NewComponent[Position]())
+		root, a, a1, b = w.CreateEntity(posID), w.CreateEntity(posID), w.CreateEntity(), w.CreateEntity(posID)
+		w.SetParent(a, root)
+		w.SetParent(a1, a)
+		w.SetParent(b, root)
+		return w, root, a, a1, b
+	}
+
+	t.Run("parent and children", func(t *testing.T) {
+		// arrange
+		w, root, a, a1, b := setup()
+
+		// act
+		children := slices.Sorted(w.Children(root))
+
+		// assert
+		if want := []Entity{a, b}; !slices.Equal(children, want) {
+			t.Errorf("unexpected result: got %v, want %v", children, want)
+		}
+		if got := w.Parent(a1); got != a {
+			t.Errorf("unexpected result: got %v, want %v", got, a)
+		}
+		if got := w.Parent(root); got != noTarget {
+			t.Errorf("unexpected result: got %v, want %v", got, noTarget)
+		}
+	})
+
+	t.Run("descendants are depth first", func(t *testing.T) {
+		// arrange
+		w, root, a, a1, _ := setup()
+
+		// act
+		got := slices.Collect(w.Descendants(root))
+
+		// assert
+		if len(got) != 3 {
+			t.Fatalf("unexpected result: got %v, want %v", len(got), 3)
+		}
+		// aの直後にaの子が現れる
+		if i := slices.Index(got, a); i < 0 || i+1 >= len(got) || got[i+1] != a1 {
+			t.Errorf("unexpected result: got %v", got)
+		}
+		if w.IsLocked() {
+			t.Errorf("world should be unlocked after iteration")
+		}
+	})
+
+	t.Run("reparent and clear", func(t *testing.T) {
+		// arrange
+		w, root, a, a1, b := setup()
+
+		// act
+		w.SetParent(a1, b)
+		w.SetParent(a, noTarget)
+
+		// assert
+		if got := slices.Collect(w.Children(b)); !slices.Equal(got, []Entity{a1}) {
+			t.Errorf("unexpected result: got %v, want %v", got, []Entity{a1})
+		}
+		if got := slices.Collect(w.Children(root)); !slices.Equal(got, []Entity{b}) {
+			t.Errorf("unexpected result: got %v, want %v", got, []Entity{b})
+		}
+	})
+
+	t.Run("orphan on remove", func(t *testing.T) {
+		// arrange
+		w, root, a, a1, b := setup()
+
+		// act
+		w.RemoveEntity(root)
+
+		// assert
+		for _, e := range []Entity{a, a1, b} {
+			if !w.Alive(e) {
+				t.Errorf("entity %v should be alive", e)
+			}
+		}
+		if got := w.Parent(a); got != noTarget {
+			t.Errorf("unexpected result: got %v, want %v", got, noTarget)
+		}
+		if got := w.Parent(a1); got != a {
+			t.Errorf("unexpected result: got %v, want %v", got, a)
+		}
+	})
+
+	t.Run("cascade on remove", func(t *testing.T) {
+		// arrange
+		w, root, a, a1, b := setup(config.WithCascadeRemove(true))
+		removed := []Entity{}
+		w.PushOnRemoveCallback(func(w *World, e Entity) {
+			removed = append(removed, e)
+		})
+
+		// act
+		w.RemoveEntity(root)
+
+		// assert
+		for _, e := range []Entity{root, a, a1, b} {
+			if w.Alive(e) {
+				t.Errorf("entity %v should be removed", e)
+			}
+		}
+		if len(removed) != 4 || removed[0] != root {
+			t.Errorf("unexpected result: got %v", removed)
+		}
+		if got := w.Stats().Entities.Used; got != 0 {
+			t.Errorf("unexpected result: got %v, want %v", got, 0)
+		}
+	})
+
+	t.Run("with another relation", func(t *testing.T) {
+		type OwnedBy struct {
+			Relation
+		}
+
+		for _, cascade := range []bool{false, true} {
+			// arrange
+			w, root, a, _, b := setup(config.WithCascadeRemove(cascade))
+			childOfID := w.childOfID()
+			ownedByID := w.RegisterComponent(NewComponent[OwnedBy]())
+			inventory := w.CreateEntity()
+			item := w.CreateEntity(ownedByID)
+			w.SetRelation(item, ownedByID, inventory)
+
+			// act
+			w.SetParent(item, a)
+			w.SetParent(b, inventory) // 別の種類のRelationのTargetも親にできる
+			q := w.Query(NewFilter().WithRelation(childOfID, a).WithRelation(ownedByID, inventory))
+			count := q.Count()
+			w.RemoveEntity(inventory)
+
+			// assert
+			if count != 1 {
+				t.Errorf("unexpected result: got %v, want %v", count, 1)
+			}
+			// カスケード削除はChildOfのみが対象で、OwnedByのTargetは未設定に戻る
+			if !w.Alive(item) {
+				t.Fatalf("item should be alive")
+			}
+			if got := w.Parent(item); got != a {
+				t.Errorf("unexpected result: got %v, want %v", got, a)
+			}
+			if got := w.GetRelation(item, ownedByID); got != noTarget {
+				t.Errorf("unexpected result: got %v, want %v", got, noTarget)
+			}
+			if got := w.Alive(b); got == cascade {
+				t.Errorf("unexpected alive: got %v, want %v", got, !cascade)
+			}
+			if got := slices.Collect(w.Descendants(root)); len(got) != 3 {
+				t.Errorf("unexpected result: got %v", got)
+			}
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		// arrange
+		w, root, _, a1, _ := setup()
+		defer func() {
+			// assert
+			r := recover()
+			if err, ok := r.(error); !ok || !errors.Is(err, ErrHierarchyCycle) {
+				t.Errorf("unexpected result: got %v, want %v", r, ErrHierarchyCycle)
+			}
+		}()
+
+		// act
+		w.SetParent(root, a1)
+	})
+}
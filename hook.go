@@ -0,0 +1,96 @@
+package ecsbit
+
+import "github.com/atEaE/ecsbit/internal/bits"
+
+// ComponentHook : Componentのライフサイクルに合わせて呼び出されるコールバック
+type ComponentHook func(w *World, e Entity)
+
+// componentHooks : 1種類のComponentに登録されたコールバック
+type componentHooks struct {
+	onAdd    []ComponentHook
+	onRemove []ComponentHook
+	onSet    []ComponentHook
+}
+
+// newComponentHookStorage : componentHookStorageを生成する
+func newComponentHookStorage() componentHookStorage {
+	return componentHookStorage{
+		hooks: make([]componentHooks, registeredComponentMaxSize),
+	}
+}
+
+// componentHookStorage : ComponentID毎のコールバックを管理するストレージ
+type componentHookStorage struct {
+	hooks []componentHooks // ComponentIDをIndexとしたコールバック
+	onAdd bits.Mask256     // OnAddが登録されているComponent
+	onRem bits.Mask256     // OnRemoveが登録されているComponent
+	onSet bits.Mask256     // OnSetが登録されているComponent
+}
+
+// OnAdd : Componentが追加された時に呼び出すコールバックを登録します
+// CreateEntityでComponentを持ったEntityを生成した場合も呼び出されます
+func (w *World) OnAdd(id ComponentID, hook ComponentHook) {
+	s := &w.componentHooks
+	s.hooks[id].onAdd = append(s.hooks[id].onAdd, hook)
+	s.onAdd.Set(uint32(id), true)
+}
+
+// OnRemove : Componentが削除される時に呼び出すコールバックを登録します
+// RemoveEntityでEntityを削除する場合も呼び出されます. 呼び出し時点ではComponentのデータを参照できます
+func (w *World) OnRemove(id ComponentID, hook ComponentHook) {
+	s := &w.componentHooks
+	s.hooks[id].onRemove = append(s.hooks[id].onRemove, hook)
+	s.onRem.Set(uint32(id), true)
+}
+
+// OnSet : 型付きのAPI（Set, TrySet）でComponentに値が設定された時に呼び出すコールバックを登録します
+func (w *World) OnSet(id ComponentID, hook ComponentHook) {
+	s := &w.componentHooks
+	s.hooks[id].onSet = append(s.hooks[id].onSet, hook)
+	s.onSet.Set(uint32(id), true)
+}
+
+// fireOnAdd : layoutに含まれるComponentのOnAddを呼び出します
+// 先に呼び出したOnAdd内でEntityが削除された場合は、残りのOnAddを呼び出しません
+func (w *World) fireOnAdd(e Entity, layout *bits.Mask256) {
+	s := &w.componentHooks
+	if !layout.Intersects(&s.onAdd) {
+		return
+	}
+	for _, id := range convertToComponentIDs(layout) {
+		for _, hook := range s.hooks[id].onAdd {
+			if !w.Alive(e) {
+				return
+			}
+			hook(w, e)
+		}
+	}
+}
+
+// fireOnRemove : layoutに含まれるComponentのOnRemoveを呼び出します
+// 先に呼び出したOnRemove内でEntityが削除された場合は、残りのOnRemoveを呼び出しません
+func (w *World) fireOnRemove(e Entity, layout *bits.Mask256) {
+	s := &w.componentHooks
+	if !layout.Intersects(&s.onRem) {
+		return
+	}
+	for _, id := range convertToComponentIDs(layout) {
+		for _, hook := range s.hooks[id].onRemove {
+			if !w.Alive(e) {
+				return
+			}
+			hook(w, e)
+		}
+	}
+}
+
+// fireOnSet : ComponentのOnSetを呼び出します
+func (w *World) fireOnSet(e Entity, id ComponentID) {
+	s := &w.componentHooks
+	if !s.onSet.Get(uint32(id)) {
+		return
+	}
+	for _, hook := range s.hooks[id].onSet {
+		hook(w, e)
+	}
+}
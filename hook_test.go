@@ -0,0 +1,128 @@
+package ecsbit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWorld_ComponentHooks(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+
+	setup := func() (*World, ComponentID, ComponentID, *[]string) {
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		log := []string{}
+		w.OnAdd(posID, func(w *World, e Entity) { log = append(log, "add:pos") })
+		w.OnAdd(velID, func(w *World, e Entity) { log = append(log, "add:vel") })
+		w.OnRemove(posID, func(w *World, e Entity) {
+			// 削除前なのでデータを参照できる
+			log = append(log, "remove:pos")
+			_ = Get[Position](w, e)
+		})
+		w.OnRemove(velID, func(w *World, e Entity) { log = append(log, "remove:vel") })
+		w.OnSet(posID, func(w *World, e Entity) { log = append(log, "set:pos") })
+		return w, posID, velID, &log
+	}
+
+	t.Run("create and remove entity", func(t *testing.T) {
+		// arrange
+		w, posID, velID, log := setup()
+
+		// act
+		e := w.CreateEntity(posID, velID)
+		w.RemoveEntity(e)
+
+		// assert
+		want := "add:pos,add:vel,remove:pos,remove:vel"
+		if got := strings.Join(*log, ","); got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("add and remove component", func(t *testing.T) {
+		// arrange
+		w, posID, velID, log := setup()
+		e := w.CreateEntity()
+
+		// act
+		w.AddComponent(e, posID, velID)
+		w.RemoveComponent(e, posID)
+
+		// assert
+		want := "add:pos,add:vel,remove:pos"
+		if got := strings.Join(*log, ","); got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+		if !Has[Velocity](w, e) || Has[Position](w, e) {
+			t.Errorf("unexpected layout")
+		}
+	})
+
+	t.Run("set", func(t *testing.T) {
+		// arrange
+		w, posID, _, log := setup()
+		e := w.CreateEntity(posID)
+
+		// act
+		Set(w, e, Position{X: 1})
+		Get[Position](w, e).X = 2 // ポインタ経由の更新ではOnSetは呼ばれない
+
+		// assert
+		want := "add:pos,set:pos"
+		if got := strings.Join(*log, ","); got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("entity removed in OnAdd", func(t *testing.T) {
+		// arrange
+		w, posID, velID, log := setup()
+		w.OnAdd(posID, func(w *World, e Entity) { w.RemoveEntity(e) })
+		w.Observe(NewFilter(posID), func(w *World, e Entity) { *log = append(*log, "enter") }, nil)
+		w.PushOnCreateCallback(func(w *World, e Entity) { *log = append(*log, "create") })
+
+		// act
+		e := w.CreateEntity(posID)
+		entities := w.CreateEntities(2, posID, velID)
+
+		// assert
+		// 削除されたEntityに対しては、以降のObserverや生成時のコールバックは呼び出されない
+		want := "add:pos,remove:pos,add:pos,remove:pos,remove:vel,add:pos,remove:pos,remove:vel"
+		if got := strings.Join(*log, ","); got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+		if w.Alive(e) || w.Alive(entities[0]) || w.Alive(entities[1]) {
+			t.Errorf("entities should be removed")
+		}
+	})
+
+	t.Run("entity removed in OnRemove", func(t *testing.T) {
+		// arrange
+		w, posID, velID, log := setup()
+		w.OnRemove(posID, func(w *World, e Entity) {
+			if w.Alive(e) {
+				w.RemoveEntity(e)
+			}
+		})
+		e := w.CreateEntity(posID, velID)
+
+		// act
+		w.RemoveComponent(e, posID, velID)
+
+		// assert
+		// OnRemove内の削除で各OnRemoveが1度ずつ呼び出され、削除済みのEntityに対しては呼び出されない
+		want := "add:pos,add:vel,remove:pos,remove:pos,remove:vel"
+		if got := strings.Join(*log, ","); got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+		if w.Alive(e) {
+			t.Errorf("entity should be removed")
+		}
+	})
+}
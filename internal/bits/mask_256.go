@@ -40,6 +40,22 @@ func (m *Mask256) Set(index uint32, value bool) {
 	}
 }
 
+// Contains : 指定したビットマスクで立っているビットが、全て立っているかどうかを判定する
+func (m *Mask256) Contains(other *Mask256) bool {
+	return m.bits[0]&other.bits[0] == other.bits[0] &&
+		m.bits[1]&other.bits[1] == other.bits[1] &&
+		m.bits[2]&other.bits[2] == other.bits[2] &&
+		m.bits[3]&other.bits[3] == other.bits[3]
+}
+
+// Intersects : 指定したビットマスクと1つでも共通して立っているビットがあるかどうかを判定する
+func (m *Mask256) Intersects(other *Mask256) bool {
+	return m.bits[0]&other.bits[0] != 0 ||
+		m.bits[1]&other.bits[1] != 0 ||
+		m.bits[2]&other.bits[2] != 0 ||
+		m.bits[3]&other.bits[3] != 0
+}
+
 // IsZero : ビットマスクが0かどうかを判定する
 func (m *Mask256) IsZero() bool {
 	return m.bits[0] == 0 && m.bits[1] == 0 && m.bits[2] == 0 && m.bits[3] == 0
@@ -6,4 +6,6 @@ type WorldConfig struct {
 	EntityPoolDefaultCapacity        uint32 // Entity Poolのキャパシティ
 	OnCreateCallbacksDefaultCapacity uint32 // Entity生成時に呼び出すコールバック群を保持するsliceのキャパシティ
 	OnRemoveCallbacksDefaultCapacity uint32 // Entity削除時に呼び出すコールバック群を保持するsliceのキャパシティ
+	IterationGuard                   bool   // Queryの走査中にWorldの構造を変更した場合にpanicさせるかどうか
+	CascadeRemove                    bool   // Entityを削除した際に子孫も削除するかどうか（falseの場合は子の親が未設定になる）
 }
@@ -0,0 +1,314 @@
+// query : Query1..Query8の型付きQueryを生成するジェネレーター
+//
+//	go run ./internal/gen/query
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"text/template"
+)
+
+const (
+	// maxComponents : 生成するQueryが扱うComponentの最大数
+	maxComponents = 8
+	// output : 生成したコードの出力先
+	output = "query_generated.go"
+)
+
+// typeParams : 型パラメータとして利用する名前
+var typeParams = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+
+// queryData : テンプレートに渡すデータ
+type queryData struct {
+	N          int      // Componentの数
+	Types      []string // 型パラメータ
+	Vars       []string // 型パラメータに対応する変数名
+	TypeParams string   // 型パラメータの宣言（A, B any）
+	TypeArgs   string   // 型パラメータの利用（A, B）
+	Returns    string   // Getの戻り値（*A, *B）
+}
+
+func main() {
+	tmpl := template.Must(template.New("query").Parse(queryTemplate))
+
+	buf := bytes.Buffer{}
+	buf.WriteString(header)
+	for n := 1; n <= maxComponents; n++ {
+		types := typeParams[:n]
+		vars := make([]string, n)
+		returns := make([]string, n)
+		for i, t := range types {
+			vars[i] = strings.ToLower(t)
+			returns[i] = "*" + t
+		}
+		data := queryData{
+			N:          n,
+			Types:      types,
+			Vars:       vars,
+			TypeParams: strings.Join(types, ", ") + " any",
+			TypeArgs:   strings.Join(types, ", "),
+			Returns:    strings.Join(returns, ", "),
+		}
+		if err := tmpl.Execute(&buf, data); err != nil {
+			panic(err)
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		panic(fmt.Errorf("format generated code: %w", err))
+	}
+	if err := os.WriteFile(output, src, 0o644); err != nil {
+		panic(err)
+	}
+}
+
+const header = `// Code generated by internal/gen/query; DO NOT EDIT.
+
+package ecsbit
+
+import "iter"
+`
+
+const queryTemplate = `
+// NewQuery{{.N}} : {{.N}}種類のComponentを持つEntityを型付きで走査するQuery{{.N}}を生成します
+// 型がWorldに登録されていない場合はErrUnregisteredComponentでpanicします
+func NewQuery{{.N}}[{{.TypeParams}}](w *World) *Query{{.N}}[{{.TypeArgs}}] {
+	q := &Query{{.N}}[{{.TypeArgs}}]{world: w}
+{{- range $i, $t := .Types}}
+	q.ids[{{$i}}] = NewField[{{$t}}](w).ID()
+{{- end}}
+	q.filter = NewFilter(q.ids[:]...)
+	return q
+}
+
+// Query{{.N}} : {{.N}}種類のComponentを持つEntityを型付きで走査するQuery
+type Query{{.N}}[{{.TypeParams}}] struct {
+	world  *World
+	ids    [{{.N}}]ComponentID
+	filter Filter
+}
+
+// With : 持っている必要があるComponentを追加します
+func (q *Query{{.N}}[{{.TypeArgs}}]) With(ids ...ComponentID) *Query{{.N}}[{{.TypeArgs}}] {
+	q.filter = q.filter.With(ids...)
+	return q
+}
+
+// Without : 持っていてはいけないComponentを追加します
+func (q *Query{{.N}}[{{.TypeArgs}}]) Without(ids ...ComponentID) *Query{{.N}}[{{.TypeArgs}}] {
+	q.filter = q.filter.Without(ids...)
+	return q
+}
+
+// Added : Sinceで指定したTickより後に追加されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query{{.N}}[{{.TypeArgs}}]) Added(ids ...ComponentID) *Query{{.N}}[{{.TypeArgs}}] {
+	q.filter = q.filter.Added(ids...)
+	return q
+}
+
+// Changed : Sinceで指定したTickより後に変更されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query{{.N}}[{{.TypeArgs}}]) Changed(ids ...ComponentID) *Query{{.N}}[{{.TypeArgs}}] {
+	q.filter = q.filter.Changed(ids...)
+	return q
+}
+
+// Since : Added, Changedの判定の基準になるTickを設定します
+func (q *Query{{.N}}[{{.TypeArgs}}]) Since(tick uint32) *Query{{.N}}[{{.TypeArgs}}] {
+	q.filter = q.filter.Since(tick)
+	return q
+}
+
+// Filter : Queryが利用するFilterを取得します
+func (q *Query{{.N}}[{{.TypeArgs}}]) Filter() Filter {
+	return q.filter
+}
+
+// Iter : Queryを走査するイテレータを取得します
+func (q *Query{{.N}}[{{.TypeArgs}}]) Iter() Query{{.N}}Iter[{{.TypeArgs}}] {
+	return Query{{.N}}Iter[{{.TypeArgs}}]{
+		query: q.world.Query(q.filter),
+		ids:   q.ids,
+	}
+}
+{{if eq .N 1}}
+// All : range-over-funcで走査するためのiter.Seq2を取得します
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します. Componentを変更する場合はAllMutを利用してください
+func (q *Query1[A]) All() iter.Seq2[Entity, *A] {
+	return q.all(false)
+}
+
+// AllMut : 変更する目的でrange-over-funcで走査するためのiter.Seq2を取得します
+// Allと異なり、走査したEntityのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query1[A]) AllMut() iter.Seq2[Entity, *A] {
+	return q.all(true)
+}
+
+// all : range-over-funcで走査するためのiter.Seq2を取得します. mutがtrueの場合は変更を記録します
+func (q *Query1[A]) all(mut bool) iter.Seq2[Entity, *A] {
+	return func(yield func(Entity, *A) bool) {
+		it := q.Iter()
+		defer it.Close()
+		for it.Next() {
+			if mut {
+				it.markChanged()
+			}
+			if !yield(it.Get()) {
+				return
+			}
+		}
+	}
+}
+{{else}}
+// All : range-over-funcで走査するためのiter.Seq2を取得します
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します. Componentを変更する場合はAllMutを利用してください
+func (q *Query{{.N}}[{{.TypeArgs}}]) All() iter.Seq2[Entity, Row{{.N}}[{{.TypeArgs}}]] {
+	return q.all(false)
+}
+
+// AllMut : 変更する目的でrange-over-funcで走査するためのiter.Seq2を取得します
+// Allと異なり、走査したEntityの全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query{{.N}}[{{.TypeArgs}}]) AllMut() iter.Seq2[Entity, Row{{.N}}[{{.TypeArgs}}]] {
+	return q.all(true)
+}
+
+// all : range-over-funcで走査するためのiter.Seq2を取得します. mutがtrueの場合は変更を記録します
+func (q *Query{{.N}}[{{.TypeArgs}}]) all(mut bool) iter.Seq2[Entity, Row{{.N}}[{{.TypeArgs}}]] {
+	return func(yield func(Entity, Row{{.N}}[{{.TypeArgs}}]) bool) {
+		it := q.Iter()
+		defer it.Close()
+		for it.Next() {
+			if mut {
+				it.markChanged()
+			}
+			entity{{range .Vars}}, {{.}}{{end}} := it.Get()
+			if !yield(entity, Row{{.N}}[{{.TypeArgs}}]{ {{- range $i, $t := .Types}}{{if $i}}, {{end}}{{$t}}: {{index $.Vars $i}}{{end -}} }) {
+				return
+			}
+		}
+	}
+}
+
+// Row{{.N}} : Query{{.N}}.Allで走査する際の1Entity分のComponent
+type Row{{.N}}[{{.TypeParams}}] struct {
+{{- range .Types}}
+	{{.}} *{{.}}
+{{- end}}
+}
+{{end}}
+// Chunks : Archetype単位で、EntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// 1件ずつ走査するよりも高速なため、大量のEntityを処理する場合に利用してください.
+// Added, ChangedはEntity単位の条件のためArchetype単位では判定できず、指定している場合はErrFilteredChunksでpanicします.
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します.
+// Componentを変更する場合はChunksMutを利用してください
+func (q *Query{{.N}}[{{.TypeArgs}}]) Chunks() iter.Seq[Chunk{{.N}}[{{.TypeArgs}}]] {
+	return q.chunks(false)
+}
+
+// ChunksMut : 変更する目的で、Archetype単位でEntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// Chunksと異なり、取得したChunkに含まれる全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query{{.N}}[{{.TypeArgs}}]) ChunksMut() iter.Seq[Chunk{{.N}}[{{.TypeArgs}}]] {
+	return q.chunks(true)
+}
+
+// chunks : Archetype単位で走査するためのiter.Seqを取得します. mutがtrueの場合は変更を記録します
+func (q *Query{{.N}}[{{.TypeArgs}}]) chunks(mut bool) iter.Seq[Chunk{{.N}}[{{.TypeArgs}}]] {
+	if q.filter.filtered() {
+		panic(ErrFilteredChunks)
+	}
+	return func(yield func(Chunk{{.N}}[{{.TypeArgs}}]) bool) {
+		query := q.world.Query(q.filter)
+		defer query.Close()
+		for query.nextArchetype() {
+			a := query.archetype
+			if mut {
+				tick := q.world.Tick()
+				for _, id := range q.ids {
+					a.Column(id).MarkAllChanged(tick)
+				}
+			}
+			chunk := Chunk{{.N}}[{{.TypeArgs}}]{
+				Entities: a.entities[:len(a.entities):len(a.entities)],
+{{- range $i, $t := .Types}}
+				{{$t}}: columnSlice[{{$t}}](a.Column(q.ids[{{$i}}])),
+{{- end}}
+			}
+			if !yield(chunk) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk{{.N}} : Query{{.N}}.Chunksで走査する際の1Archetype分のデータ
+// 各sliceの長さはEntitiesと一致し、同じIndexの要素が同じEntityのComponentを表します
+type Chunk{{.N}}[{{.TypeParams}}] struct {
+	Entities []Entity
+{{- range .Types}}
+	{{.}} []{{.}}
+{{- end}}
+}
+
+// Query{{.N}}Iter : Query{{.N}}を走査するイテレータ
+type Query{{.N}}Iter[{{.TypeParams}}] struct {
+	query     Query
+	ids       [{{.N}}]ComponentID
+	archetype *archetype
+	columns   [{{.N}}]*column
+}
+
+// Next : 次のEntityへ進みます. 走査が終了した場合はfalseを返します
+func (it *Query{{.N}}Iter[{{.TypeArgs}}]) Next() bool {
+	if !it.query.Next() {
+		return false
+	}
+	// Archetypeが切り替わった場合のみcolumnを解決し直す
+	if it.query.archetype != it.archetype {
+		it.archetype = it.query.archetype
+		for i := range it.ids {
+			it.columns[i] = it.archetype.Column(it.ids[i])
+		}
+	}
+	return true
+}
+
+// Close : 走査を終了し、Worldのロックを解除します
+// 最後まで走査した場合は自動的に呼び出されるため、途中で走査を抜ける場合のみ呼び出してください
+func (it *Query{{.N}}Iter[{{.TypeArgs}}]) Close() {
+	it.query.Close()
+}
+
+// Entity : 走査中のEntityを取得します
+func (it *Query{{.N}}Iter[{{.TypeArgs}}]) Entity() Entity {
+	return it.query.Entity()
+}
+
+// Get : 走査中のEntityとComponentを取得します
+// 変更は記録されないため、Componentを変更する場合はGetMutを利用してください
+func (it *Query{{.N}}Iter[{{.TypeArgs}}]) Get() (Entity, {{.Returns}}) {
+	index := it.query.index
+	return it.archetype.GetEntity(index)
+{{- range $i, $t := .Types}}, ({{print "*" $t}})(it.columns[{{$i}}].Get(index)){{end}}
+}
+
+// GetMut : 変更する目的で走査中のEntityとComponentを取得します
+// Getと異なり、全てのComponentが現在のTickで変更されたものとして記録されます
+func (it *Query{{.N}}Iter[{{.TypeArgs}}]) GetMut() (Entity, {{.Returns}}) {
+	it.markChanged()
+	return it.Get()
+}
+
+// markChanged : 走査中のEntityの全てのComponentを、現在のTickで変更されたものとして記録します
+func (it *Query{{.N}}Iter[{{.TypeArgs}}]) markChanged() {
+	tick := it.query.world.Tick()
+	for _, c := range it.columns {
+		c.MarkChanged(it.query.index, tick)
+	}
+}
+`
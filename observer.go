@@ -0,0 +1,160 @@
+package ecsbit
+
+// ObserverCallback : Observerに登録するコールバック
+type ObserverCallback func(w *World, e Entity)
+
+// Observe : Filterに一致するEntityの集合への出入りを監視するObserverを登録します
+// onEnterはEntityがFilterに一致するようになった時（生成、Componentの追加、削除による移動）に呼び出され、
+// onExitはEntityがFilterに一致しなくなった時（削除、Componentの追加、削除による移動）に呼び出されます.
+// Entity、Componentの削除の場合は削除前に呼び出されるため、削除されるComponentのデータを参照できます. 不要なコールバックにはnilを指定できます
+func (w *World) Observe(f Filter, onEnter, onExit ObserverCallback) *Observer {
+	o := &Observer{
+		world:   w,
+		filter:  f,
+		onEnter: onEnter,
+		onExit:  onExit,
+	}
+	w.observers = append(w.observers, o)
+	return o
+}
+
+// Observer : Filterに一致するEntityの集合への出入りを監視する構造体
+type Observer struct {
+	world    *World
+	filter   Filter
+	onEnter  ObserverCallback
+	onExit   ObserverCallback
+	released bool
+}
+
+// Released : 登録が解除されているかどうかを返します
+func (o *Observer) Released() bool {
+	return o.released
+}
+
+// Release : 登録を解除します
+// コールバックの呼び出し中に解除することもできます
+func (o *Observer) Release() {
+	if o.released {
+		return
+	}
+	o.released = true
+	o.world.observersDirty = true
+}
+
+// notifyEnter : 移動元では一致せず、移動先で一致するObserverのonEnterを呼び出します
+// 生成の場合は移動元にnilを指定します
+func (w *World) notifyEnter(e Entity, from, to *archetype) {
+	if len(w.observers) == 0 {
+		return
+	}
+	w.compactObservers()
+	w.notifying++
+	defer func() { w.notifying-- }()
+	for i := 0; i < len(w.observers); i++ {
+		o := w.observers[i]
+		if o.released || o.onEnter == nil || !o.observes(w, e, to) {
+			continue
+		}
+		if from != nil && o.observes(w, e, from) {
+			continue
+		}
+		o.onEnter(w, e)
+	}
+}
+
+// notifyExit : 移動元で一致し、移動先では一致しないObserverのonExitを呼び出します
+// 削除の場合は移動先にnilを指定します
+func (w *World) notifyExit(e Entity, from, to *archetype) {
+	if len(w.observers) == 0 {
+		return
+	}
+	w.compactObservers()
+	w.notifying++
+	defer func() { w.notifying-- }()
+	for i := 0; i < len(w.observers); i++ {
+		o := w.observers[i]
+		if o.released || o.onExit == nil || !o.observes(w, e, from) {
+			continue
+		}
+		if to != nil && o.observes(w, e, to) {
+			continue
+		}
+		o.onExit(w, e)
+	}
+}
+
+// observing : Entityの現在の状態が、各ObserverのFilterに一致しているかどうかを取得します
+// 結果のIndexはobserversのIndexと一致します. RelationのTargetを変更する前の状態を記録するために利用します
+func (w *World) observing(e Entity) []bool {
+	if len(w.observers) == 0 {
+		return nil
+	}
+	w.compactObservers()
+	a := w.entityIndices[e.ID()].archetype
+	matched := make([]bool, len(w.observers))
+	for i, o := range w.observers {
+		matched[i] = !o.released && o.observes(w, e, a)
+	}
+	return matched
+}
+
+// notifyRetarget : RelationのTargetの変更によって、Filterへの一致が変わったObserverのonExit、onEnterを呼び出します
+// beforeには、変更前にobservingで取得した結果を指定します
+func (w *World) notifyRetarget(e Entity, before []bool) {
+	if len(before) == 0 {
+		return
+	}
+	w.notifying++
+	defer func() { w.notifying-- }()
+	a := w.entityIndices[e.ID()].archetype
+	after := make([]bool, len(before))
+	for i := range before {
+		after[i] = w.observers[i].observes(w, e, a)
+	}
+	for i := range before {
+		if o := w.observers[i]; before[i] && !after[i] && !o.released && o.onExit != nil {
+			o.onExit(w, e)
+		}
+	}
+	for i := range before {
+		// onExit内で削除された場合は、onEnterを呼び出さない
+		if !w.Alive(e) {
+			return
+		}
+		if o := w.observers[i]; !before[i] && after[i] && !o.released && o.onEnter != nil {
+			o.onEnter(w, e)
+		}
+	}
+}
+
+// observes : 指定したArchetypeのLayoutと、EntityのRelationのTargetがFilterに一致するかどうかを返します
+// WithRelationの条件に含まれるRelationはFilterが必須とするため、移動元と移動先の両方で判定が必要になるのは
+// Relationを持ち続ける場合のみで、その場合はTargetが引き継がれる. そのためTargetは常にEntityの現在の状態で判定する
+func (o *Observer) observes(w *World, e Entity, a *archetype) bool {
+	if !o.filter.Matches(&a.layoutMask) {
+		return false
+	}
+	if len(o.filter.relations) == 0 {
+		return true
+	}
+	index := &w.entityIndices[e.ID()]
+	return o.filter.matchesTargets(index.archetype, index.index)
+}
+
+// compactObservers : 解除されたObserverを取り除きます
+// 呼び出し中のIndexをずらさないよう、通知の開始時にのみ行います
+func (w *World) compactObservers() {
+	if !w.observersDirty || w.notifying > 0 {
+		return
+	}
+	alive := w.observers[:0]
+	for _, o := range w.observers {
+		if !o.released {
+			alive = append(alive, o)
+		}
+	}
+	clear(w.observers[len(alive):])
+	w.observers = alive
+	w.observersDirty = false
+}
@@ -0,0 +1,131 @@
+package ecsbit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWorld_Observe(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+	type Frozen struct{}
+
+	setup := func() (*World, ComponentID, ComponentID, ComponentID, *[]string, *Observer) {
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		frozenID := w.RegisterComponent(NewComponent[Frozen]())
+		log := []string{}
+		o := w.Observe(
+			NewFilter(posID, velID).Without(frozenID),
+			func(w *World, e Entity) { log = append(log, "enter") },
+			func(w *World, e Entity) { log = append(log, "exit") },
+		)
+		return w, posID, velID, frozenID, &log, o
+	}
+
+	t.Run("create and remove", func(t *testing.T) {
+		// arrange
+		w, posID, velID, _, log, _ := setup()
+
+		// act
+		e := w.CreateEntity(posID, velID)
+		w.CreateEntity(posID)
+		w.RemoveEntity(e)
+
+		// assert
+		if got := strings.Join(*log, ","); got != "enter,exit" {
+			t.Errorf("unexpected result: got %v, want %v", got, "enter,exit")
+		}
+	})
+
+	t.Run("migration", func(t *testing.T) {
+		// arrange
+		w, posID, velID, frozenID, log, _ := setup()
+		e := w.CreateEntity(posID)
+
+		// act
+		w.AddComponent(e, velID)       // enter
+		w.AddComponent(e, frozenID)    // exit
+		w.RemoveComponent(e, frozenID) // enter
+		w.RemoveComponent(e, posID)    // exit
+		w.AddComponent(e, frozenID)    // no change
+
+		// assert
+		want := "enter,exit,enter,exit"
+		if got := strings.Join(*log, ","); got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("exit reads removed component", func(t *testing.T) {
+		// arrange
+		w, posID, velID, _, _, _ := setup()
+		e := w.CreateEntity(posID, velID)
+		Set(w, e, Position{X: 1, Y: 2})
+		var got Position
+		var err error
+		w.Observe(NewFilter(posID), nil, func(w *World, e Entity) {
+			var pos *Position
+			if pos, err = TryGet[Position](w, e); err == nil {
+				got = *pos
+			}
+		})
+
+		// act
+		w.RemoveComponent(e, posID)
+
+		// assert
+		// 削除前に呼び出されるため、削除されるComponentを参照できる
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != (Position{X: 1, Y: 2}) {
+			t.Errorf("unexpected result: got %v, want %v", got, Position{X: 1, Y: 2})
+		}
+		if Has[Position](w, e) {
+			t.Errorf("component should be removed")
+		}
+	})
+
+	t.Run("entity removed in OnAdd", func(t *testing.T) {
+		// arrange
+		w, posID, velID, _, log, _ := setup()
+		w.OnAdd(velID, func(w *World, e Entity) { w.RemoveEntity(e) })
+		e := w.CreateEntity(posID)
+
+		// act
+		w.AddComponent(e, velID)
+
+		// assert
+		// OnAdd内の削除によるonExitのみ呼び出され、削除されたEntityに対してonEnterは呼び出されない
+		want := "exit"
+		if got := strings.Join(*log, ","); got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+		if w.Alive(e) {
+			t.Errorf("entity should be removed")
+		}
+	})
+
+	t.Run("release", func(t *testing.T) {
+		// arrange
+		w, posID, velID, _, log, o := setup()
+
+		// act
+		o.Release()
+		w.CreateEntity(posID, velID)
+
+		// assert
+		if len(*log) != 0 {
+			t.Errorf("unexpected result: %v", *log)
+		}
+		if len(w.observers) != 0 {
+			t.Errorf("unexpected observer count: %d", len(w.observers))
+		}
+	})
+}
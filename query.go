@@ -0,0 +1,260 @@
+package ecsbit
+
+import (
+	"iter"
+	"unsafe"
+)
+
+//go:generate go run ./internal/gen/query
+
+// Query : Filterに一致するArchetypeに属する全てのEntityを走査します
+// 作成したWorldに生成済みのArchetypeを対象とし、Archetype単位でIndex順に走査します.
+// WithRelationでTargetを指定した場合は、Archetypeではなく、そのTargetを設定しているEntityだけを走査します.
+// 走査中はWorldがロックされ、Entityの生成、削除やComponentの追加、削除はpanicします.
+// Nextで走査する場合、最後まで走査せずに抜ける時はCloseを呼び出してロックを解除してください.
+// 途中で抜ける可能性がある場合は、抜けた時点で自動的にロックを解除するAllを利用してください
+//
+//	q := w.Query(ecsbit.NewFilter(posID))
+//	for e := range q.All() {
+//		...
+//	}
+func (w *World) Query(f Filter) Query {
+	return Query{
+		world:          w,
+		filter:         f,
+		archetypes:     w.archetypes,
+		archetypeIndex: -1,
+		filtered:       f.filtered(),
+		sourced:        f.sourced(),
+	}
+}
+
+// Query : Filterに一致するEntityを走査するイテレータ
+type Query struct {
+	world          *World
+	filter         Filter
+	archetypes     []*archetype // 走査対象のArchetype
+	archetypeIndex int          // 走査中のArchetypeのarchetypes内でのIndex
+	archetype      *archetype   // 走査中のArchetype
+	index          uint32       // 走査中のEntityのArchetype内でのIndex
+	matched        bool         // archetypesが全てFilterに一致しているかどうか（CachedQueryから生成した場合はtrue）
+	locked         bool         // Worldをロックしているかどうか
+	filtered       bool         // Added, Changed, WithRelationによるEntity単位の判定が必要かどうか
+	sourced        bool         // WithRelationのTargetを設定しているEntityを走査するかどうか
+	sources        []Entity     // 走査対象のTargetを設定しているEntity
+	sourceIndex    int          // 次に走査するEntityのsources内でのIndex
+	added          []*column    // 走査中のArchetypeで、追加されたTickを判定するcolumn
+	changed        []*column    // 走査中のArchetypeで、変更されたTickを判定するcolumn
+}
+
+// Next : 次のEntityへ進みます. 走査が終了した場合はfalseを返します
+func (q *Query) Next() bool {
+	if q.sourced {
+		return q.nextSource()
+	}
+	if !q.filtered {
+		return q.next()
+	}
+	for q.next() {
+		if q.matchesRow() {
+			return true
+		}
+	}
+	return false
+}
+
+// next : Added, Changed, WithRelationの条件を考慮せずに次のEntityへ進みます
+func (q *Query) next() bool {
+	if q.archetype != nil && q.index+1 < uint32(q.archetype.Count()) {
+		q.index++
+		return true
+	}
+	return q.nextArchetype()
+}
+
+// matchesRow : 走査中のEntityがAdded, Changed, WithRelationの条件を満たすかどうかを返します
+func (q *Query) matchesRow() bool {
+	for _, c := range q.added {
+		if c.added[q.index] <= q.filter.since {
+			return false
+		}
+	}
+	for _, c := range q.changed {
+		if c.changed[q.index] <= q.filter.since {
+			return false
+		}
+	}
+	return q.filter.matchesTargets(q.archetype, q.index)
+}
+
+// filterColumns : 走査中のArchetypeから、Added, Changedの判定に利用するcolumnを取得します
+func (q *Query) filterColumns() {
+	q.added, q.changed = q.added[:0], q.changed[:0]
+	for _, id := range convertToComponentIDs(&q.filter.added) {
+		q.added = append(q.added, q.archetype.Column(id))
+	}
+	for _, id := range convertToComponentIDs(&q.filter.changed) {
+		q.changed = append(q.changed, q.archetype.Column(id))
+	}
+}
+
+// nextArchetype : Filterに一致する次のArchetypeへ進みます
+// 最初の呼び出しでWorldをロックし、走査が終了した時点でロックを解除します
+func (q *Query) nextArchetype() bool {
+	if !q.locked && q.archetypeIndex < 0 {
+		q.world.lock()
+		q.locked = true
+	}
+	for q.archetypeIndex+1 < len(q.archetypes) {
+		q.archetypeIndex++
+		a := q.archetypes[q.archetypeIndex]
+		if a.Count() == 0 || (!q.matched && !q.filter.Matches(&a.layoutMask)) {
+			continue
+		}
+		q.archetype, q.index = a, 0
+		if q.filtered {
+			q.filterColumns()
+		}
+		return true
+	}
+	q.Close()
+	return false
+}
+
+// nextSource : WithRelationのTargetを設定しているEntityのうち、Filterの条件を満たす次のEntityへ進みます
+// Relation毎のTargetの逆引きを利用するため、Relationを持つEntityの総数ではなく、Targetを設定しているEntityの数だけ走査します
+func (q *Query) nextSource() bool {
+	if !q.locked && q.archetypeIndex < 0 {
+		q.world.lock()
+		q.locked = true
+		q.archetypeIndex = 0
+		q.sources = q.filter.relationSources(q.world)
+	}
+	for q.sourceIndex < len(q.sources) {
+		e := q.sources[q.sourceIndex]
+		q.sourceIndex++
+		index := &q.world.entityIndices[e.ID()]
+		if !q.filter.Matches(&index.archetype.layoutMask) {
+			continue
+		}
+		if q.archetype != index.archetype {
+			q.archetype = index.archetype
+			q.filterColumns()
+		}
+		q.index = index.index
+		if q.matchesRow() {
+			return true
+		}
+	}
+	q.Close()
+	return false
+}
+
+// All : range-over-funcで走査するためのiter.Seqを取得します
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します. Get, Hasなどは走査中のEntityに対して呼び出せます
+func (q *Query) All() iter.Seq[Entity] {
+	return func(yield func(Entity) bool) {
+		defer q.Close()
+		for q.Next() {
+			if !yield(q.Entity()) {
+				return
+			}
+		}
+	}
+}
+
+// Close : 走査を終了し、Worldのロックを解除します
+// 最後まで走査した場合は自動的に呼び出されるため、途中で走査を抜ける場合のみ呼び出してください
+func (q *Query) Close() {
+	q.archetype = nil
+	q.archetypeIndex = len(q.archetypes)
+	q.sources, q.sourceIndex = nil, 0
+	if q.locked {
+		q.world.unlock()
+		q.locked = false
+	}
+}
+
+// Entity : 走査中のEntityを取得します
+func (q *Query) Entity() Entity {
+	return q.archetype.GetEntity(q.index)
+}
+
+// Has : 走査中のEntityが指定したComponentを持っているかどうかを返します
+// Optionalに指定したComponentの有無を確認する場合に利用します
+func (q *Query) Has(id ComponentID) bool {
+	return q.archetype.HasComponent(id)
+}
+
+// Get : 走査中のEntityが持つComponentのデータを指すポインタを取得します
+// 持っていない場合はnilを返します. 型付きで参照したい場合はFieldを利用してください
+func (q *Query) Get(id ComponentID) unsafe.Pointer {
+	c := q.archetype.Column(id)
+	if c == nil {
+		return nil
+	}
+	return c.Get(q.index)
+}
+
+// Count : Filterに一致するEntityの総数を取得します
+// Added, Changed, WithRelationを指定している場合は、Entity単位で判定するため走査と同じコストがかかります
+func (q *Query) Count() int {
+	if q.filtered {
+		count := 0
+		counter := Query{world: q.world, filter: q.filter, archetypes: q.archetypes, archetypeIndex: -1, matched: q.matched, filtered: true, sourced: q.sourced}
+		for counter.Next() {
+			count++
+		}
+		return count
+	}
+
+	count := 0
+	for _, a := range q.archetypes {
+		if q.matched || q.filter.Matches(&a.layoutMask) {
+			count += a.Count()
+		}
+	}
+	return count
+}
+
+// NewField : 型からComponentIDを解決し、Queryから型付きでComponentを参照するためのFieldを生成します
+// 型がWorldに登録されていない場合はErrUnregisteredComponentでpanicします
+func NewField[T any](w *World) Field[T] {
+	id, err := componentIDOf[T](w)
+	if err != nil {
+		panic(err)
+	}
+	return Field[T]{id: id}
+}
+
+// Field : Queryから型付きでComponentを参照するためのアクセサ
+// ComponentIDの解決は生成時に1度だけ行うため、走査中はreflectionを利用しません
+type Field[T any] struct {
+	id ComponentID
+}
+
+// ID : FieldのComponentIDを取得します
+func (f Field[T]) ID() ComponentID {
+	return f.id
+}
+
+// Get : 走査中のEntityが持つComponentを取得します. 持っていない場合はnilを返します
+func (f Field[T]) Get(q *Query) *T {
+	return (*T)(q.Get(f.id))
+}
+
+// GetMut : 変更する目的で走査中のEntityが持つComponentを取得します. 持っていない場合はnilを返します
+// Getと異なり、Componentが現在のTickで変更されたものとして記録されます
+func (f Field[T]) GetMut(q *Query) *T {
+	c := q.archetype.Column(f.id)
+	if c == nil {
+		return nil
+	}
+	c.MarkChanged(q.index, q.world.Tick())
+	return (*T)(c.Get(q.index))
+}
+
+// Has : 走査中のEntityがComponentを持っているかどうかを返します
+func (f Field[T]) Has(q *Query) bool {
+	return q.Has(f.id)
+}
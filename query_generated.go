@@ -0,0 +1,1711 @@
+// Code generated by internal/gen/query; DO NOT EDIT.
+
+package ecsbit
+
+import "iter"
+
+// NewQuery1 : 1種類のComponentを持つEntityを型付きで走査するQuery1を生成します
+// 型がWorldに登録されていない場合はErrUnregisteredComponentでpanicします
+func NewQuery1[A any](w *World) *Query1[A] {
+	q := &Query1[A]{world: w}
+	q.ids[0] = NewField[A](w).ID()
+	q.filter = NewFilter(q.ids[:]...)
+	return q
+}
+
+// Query1 : 1種類のComponentを持つEntityを型付きで走査するQuery
+type Query1[A any] struct {
+	world  *World
+	ids    [1]ComponentID
+	filter Filter
+}
+
+// With : 持っている必要があるComponentを追加します
+func (q *Query1[A]) With(ids ...ComponentID) *Query1[A] {
+	q.filter = q.filter.With(ids...)
+	return q
+}
+
+// Without : 持っていてはいけないComponentを追加します
+func (q *Query1[A]) Without(ids ...ComponentID) *Query1[A] {
+	q.filter = q.filter.Without(ids...)
+	return q
+}
+
+// Added : Sinceで指定したTickより後に追加されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query1[A]) Added(ids ...ComponentID) *Query1[A] {
+	q.filter = q.filter.Added(ids...)
+	return q
+}
+
+// Changed : Sinceで指定したTickより後に変更されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query1[A]) Changed(ids ...ComponentID) *Query1[A] {
+	q.filter = q.filter.Changed(ids...)
+	return q
+}
+
+// Since : Added, Changedの判定の基準になるTickを設定します
+func (q *Query1[A]) Since(tick uint32) *Query1[A] {
+	q.filter = q.filter.Since(tick)
+	return q
+}
+
+// Filter : Queryが利用するFilterを取得します
+func (q *Query1[A]) Filter() Filter {
+	return q.filter
+}
+
+// Iter : Queryを走査するイテレータを取得します
+func (q *Query1[A]) Iter() Query1Iter[A] {
+	return Query1Iter[A]{
+		query: q.world.Query(q.filter),
+		ids:   q.ids,
+	}
+}
+
+// All : range-over-funcで走査するためのiter.Seq2を取得します
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します. Componentを変更する場合はAllMutを利用してください
+func (q *Query1[A]) All() iter.Seq2[Entity, *A] {
+	return q.all(false)
+}
+
+// AllMut : 変更する目的でrange-over-funcで走査するためのiter.Seq2を取得します
+// Allと異なり、走査したEntityのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query1[A]) AllMut() iter.Seq2[Entity, *A] {
+	return q.all(true)
+}
+
+// all : range-over-funcで走査するためのiter.Seq2を取得します. mutがtrueの場合は変更を記録します
+func (q *Query1[A]) all(mut bool) iter.Seq2[Entity, *A] {
+	return func(yield func(Entity, *A) bool) {
+		it := q.Iter()
+		defer it.Close()
+		for it.Next() {
+			if mut {
+				it.markChanged()
+			}
+			if !yield(it.Get()) {
+				return
+			}
+		}
+	}
+}
+
+// Chunks : Archetype単位で、EntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// 1件ずつ走査するよりも高速なため、大量のEntityを処理する場合に利用してください.
+// Added, ChangedはEntity単位の条件のためArchetype単位では判定できず、指定している場合はErrFilteredChunksでpanicします.
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します.
+// Componentを変更する場合はChunksMutを利用してください
+func (q *Query1[A]) Chunks() iter.Seq[Chunk1[A]] {
+	return q.chunks(false)
+}
+
+// ChunksMut : 変更する目的で、Archetype単位でEntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// Chunksと異なり、取得したChunkに含まれる全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query1[A]) ChunksMut() iter.Seq[Chunk1[A]] {
+	return q.chunks(true)
+}
+
+// chunks : Archetype単位で走査するためのiter.Seqを取得します. mutがtrueの場合は変更を記録します
+func (q *Query1[A]) chunks(mut bool) iter.Seq[Chunk1[A]] {
+	if q.filter.filtered() {
+		panic(ErrFilteredChunks)
+	}
+	return func(yield func(Chunk1[A]) bool) {
+		query := q.world.Query(q.filter)
+		defer query.Close()
+		for query.nextArchetype() {
+			a := query.archetype
+			if mut {
+				tick := q.world.Tick()
+				for _, id := range q.ids {
+					a.Column(id).MarkAllChanged(tick)
+				}
+			}
+			chunk := Chunk1[A]{
+				Entities: a.entities[:len(a.entities):len(a.entities)],
+				A:        columnSlice[A](a.Column(q.ids[0])),
+			}
+			if !yield(chunk) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk1 : Query1.Chunksで走査する際の1Archetype分のデータ
+// 各sliceの長さはEntitiesと一致し、同じIndexの要素が同じEntityのComponentを表します
+type Chunk1[A any] struct {
+	Entities []Entity
+	A        []A
+}
+
+// Query1Iter : Query1を走査するイテレータ
+type Query1Iter[A any] struct {
+	query     Query
+	ids       [1]ComponentID
+	archetype *archetype
+	columns   [1]*column
+}
+
+// Next : 次のEntityへ進みます. 走査が終了した場合はfalseを返します
+func (it *Query1Iter[A]) Next() bool {
+	if !it.query.Next() {
+		return false
+	}
+	// Archetypeが切り替わった場合のみcolumnを解決し直す
+	if it.query.archetype != it.archetype {
+		it.archetype = it.query.archetype
+		for i := range it.ids {
+			it.columns[i] = it.archetype.Column(it.ids[i])
+		}
+	}
+	return true
+}
+
+// Close : 走査を終了し、Worldのロックを解除します
+// 最後まで走査した場合は自動的に呼び出されるため、途中で走査を抜ける場合のみ呼び出してください
+func (it *Query1Iter[A]) Close() {
+	it.query.Close()
+}
+
+// Entity : 走査中のEntityを取得します
+func (it *Query1Iter[A]) Entity() Entity {
+	return it.query.Entity()
+}
+
+// Get : 走査中のEntityとComponentを取得します
+// 変更は記録されないため、Componentを変更する場合はGetMutを利用してください
+func (it *Query1Iter[A]) Get() (Entity, *A) {
+	index := it.query.index
+	return it.archetype.GetEntity(index), (*A)(it.columns[0].Get(index))
+}
+
+// GetMut : 変更する目的で走査中のEntityとComponentを取得します
+// Getと異なり、全てのComponentが現在のTickで変更されたものとして記録されます
+func (it *Query1Iter[A]) GetMut() (Entity, *A) {
+	it.markChanged()
+	return it.Get()
+}
+
+// markChanged : 走査中のEntityの全てのComponentを、現在のTickで変更されたものとして記録します
+func (it *Query1Iter[A]) markChanged() {
+	tick := it.query.world.Tick()
+	for _, c := range it.columns {
+		c.MarkChanged(it.query.index, tick)
+	}
+}
+
+// NewQuery2 : 2種類のComponentを持つEntityを型付きで走査するQuery2を生成します
+// 型がWorldに登録されていない場合はErrUnregisteredComponentでpanicします
+func NewQuery2[A, B any](w *World) *Query2[A, B] {
+	q := &Query2[A, B]{world: w}
+	q.ids[0] = NewField[A](w).ID()
+	q.ids[1] = NewField[B](w).ID()
+	q.filter = NewFilter(q.ids[:]...)
+	return q
+}
+
+// Query2 : 2種類のComponentを持つEntityを型付きで走査するQuery
+type Query2[A, B any] struct {
+	world  *World
+	ids    [2]ComponentID
+	filter Filter
+}
+
+// With : 持っている必要があるComponentを追加します
+func (q *Query2[A, B]) With(ids ...ComponentID) *Query2[A, B] {
+	q.filter = q.filter.With(ids...)
+	return q
+}
+
+// Without : 持っていてはいけないComponentを追加します
+func (q *Query2[A, B]) Without(ids ...ComponentID) *Query2[A, B] {
+	q.filter = q.filter.Without(ids...)
+	return q
+}
+
+// Added : Sinceで指定したTickより後に追加されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query2[A, B]) Added(ids ...ComponentID) *Query2[A, B] {
+	q.filter = q.filter.Added(ids...)
+	return q
+}
+
+// Changed : Sinceで指定したTickより後に変更されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query2[A, B]) Changed(ids ...ComponentID) *Query2[A, B] {
+	q.filter = q.filter.Changed(ids...)
+	return q
+}
+
+// Since : Added, Changedの判定の基準になるTickを設定します
+func (q *Query2[A, B]) Since(tick uint32) *Query2[A, B] {
+	q.filter = q.filter.Since(tick)
+	return q
+}
+
+// Filter : Queryが利用するFilterを取得します
+func (q *Query2[A, B]) Filter() Filter {
+	return q.filter
+}
+
+// Iter : Queryを走査するイテレータを取得します
+func (q *Query2[A, B]) Iter() Query2Iter[A, B] {
+	return Query2Iter[A, B]{
+		query: q.world.Query(q.filter),
+		ids:   q.ids,
+	}
+}
+
+// All : range-over-funcで走査するためのiter.Seq2を取得します
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します. Componentを変更する場合はAllMutを利用してください
+func (q *Query2[A, B]) All() iter.Seq2[Entity, Row2[A, B]] {
+	return q.all(false)
+}
+
+// AllMut : 変更する目的でrange-over-funcで走査するためのiter.Seq2を取得します
+// Allと異なり、走査したEntityの全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query2[A, B]) AllMut() iter.Seq2[Entity, Row2[A, B]] {
+	return q.all(true)
+}
+
+// all : range-over-funcで走査するためのiter.Seq2を取得します. mutがtrueの場合は変更を記録します
+func (q *Query2[A, B]) all(mut bool) iter.Seq2[Entity, Row2[A, B]] {
+	return func(yield func(Entity, Row2[A, B]) bool) {
+		it := q.Iter()
+		defer it.Close()
+		for it.Next() {
+			if mut {
+				it.markChanged()
+			}
+			entity, a, b := it.Get()
+			if !yield(entity, Row2[A, B]{A: a, B: b}) {
+				return
+			}
+		}
+	}
+}
+
+// Row2 : Query2.Allで走査する際の1Entity分のComponent
+type Row2[A, B any] struct {
+	A *A
+	B *B
+}
+
+// Chunks : Archetype単位で、EntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// 1件ずつ走査するよりも高速なため、大量のEntityを処理する場合に利用してください.
+// Added, ChangedはEntity単位の条件のためArchetype単位では判定できず、指定している場合はErrFilteredChunksでpanicします.
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します.
+// Componentを変更する場合はChunksMutを利用してください
+func (q *Query2[A, B]) Chunks() iter.Seq[Chunk2[A, B]] {
+	return q.chunks(false)
+}
+
+// ChunksMut : 変更する目的で、Archetype単位でEntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// Chunksと異なり、取得したChunkに含まれる全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query2[A, B]) ChunksMut() iter.Seq[Chunk2[A, B]] {
+	return q.chunks(true)
+}
+
+// chunks : Archetype単位で走査するためのiter.Seqを取得します. mutがtrueの場合は変更を記録します
+func (q *Query2[A, B]) chunks(mut bool) iter.Seq[Chunk2[A, B]] {
+	if q.filter.filtered() {
+		panic(ErrFilteredChunks)
+	}
+	return func(yield func(Chunk2[A, B]) bool) {
+		query := q.world.Query(q.filter)
+		defer query.Close()
+		for query.nextArchetype() {
+			a := query.archetype
+			if mut {
+				tick := q.world.Tick()
+				for _, id := range q.ids {
+					a.Column(id).MarkAllChanged(tick)
+				}
+			}
+			chunk := Chunk2[A, B]{
+				Entities: a.entities[:len(a.entities):len(a.entities)],
+				A:        columnSlice[A](a.Column(q.ids[0])),
+				B:        columnSlice[B](a.Column(q.ids[1])),
+			}
+			if !yield(chunk) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk2 : Query2.Chunksで走査する際の1Archetype分のデータ
+// 各sliceの長さはEntitiesと一致し、同じIndexの要素が同じEntityのComponentを表します
+type Chunk2[A, B any] struct {
+	Entities []Entity
+	A        []A
+	B        []B
+}
+
+// Query2Iter : Query2を走査するイテレータ
+type Query2Iter[A, B any] struct {
+	query     Query
+	ids       [2]ComponentID
+	archetype *archetype
+	columns   [2]*column
+}
+
+// Next : 次のEntityへ進みます. 走査が終了した場合はfalseを返します
+func (it *Query2Iter[A, B]) Next() bool {
+	if !it.query.Next() {
+		return false
+	}
+	// Archetypeが切り替わった場合のみcolumnを解決し直す
+	if it.query.archetype != it.archetype {
+		it.archetype = it.query.archetype
+		for i := range it.ids {
+			it.columns[i] = it.archetype.Column(it.ids[i])
+		}
+	}
+	return true
+}
+
+// Close : 走査を終了し、Worldのロックを解除します
+// 最後まで走査した場合は自動的に呼び出されるため、途中で走査を抜ける場合のみ呼び出してください
+func (it *Query2Iter[A, B]) Close() {
+	it.query.Close()
+}
+
+// Entity : 走査中のEntityを取得します
+func (it *Query2Iter[A, B]) Entity() Entity {
+	return it.query.Entity()
+}
+
+// Get : 走査中のEntityとComponentを取得します
+// 変更は記録されないため、Componentを変更する場合はGetMutを利用してください
+func (it *Query2Iter[A, B]) Get() (Entity, *A, *B) {
+	index := it.query.index
+	return it.archetype.GetEntity(index), (*A)(it.columns[0].Get(index)), (*B)(it.columns[1].Get(index))
+}
+
+// GetMut : 変更する目的で走査中のEntityとComponentを取得します
+// Getと異なり、全てのComponentが現在のTickで変更されたものとして記録されます
+func (it *Query2Iter[A, B]) GetMut() (Entity, *A, *B) {
+	it.markChanged()
+	return it.Get()
+}
+
+// markChanged : 走査中のEntityの全てのComponentを、現在のTickで変更されたものとして記録します
+func (it *Query2Iter[A, B]) markChanged() {
+	tick := it.query.world.Tick()
+	for _, c := range it.columns {
+		c.MarkChanged(it.query.index, tick)
+	}
+}
+
+// NewQuery3 : 3種類のComponentを持つEntityを型付きで走査するQuery3を生成します
+// 型がWorldに登録されていない場合はErrUnregisteredComponentでpanicします
+func NewQuery3[A, B, C any](w *World) *Query3[A, B, C] {
+	q := &Query3[A, B, C]{world: w}
+	q.ids[0] = NewField[A](w).ID()
+	q.ids[1] = NewField[B](w).ID()
+	q.ids[2] = NewField[C](w).ID()
+	q.filter = NewFilter(q.ids[:]...)
+	return q
+}
+
+// Query3 : 3種類のComponentを持つEntityを型付きで走査するQuery
+type Query3[A, B, C any] struct {
+	world  *World
+	ids    [3]ComponentID
+	filter Filter
+}
+
+// With : 持っている必要があるComponentを追加します
+func (q *Query3[A, B, C]) With(ids ...ComponentID) *Query3[A, B, C] {
+	q.filter = q.filter.With(ids...)
+	return q
+}
+
+// Without : 持っていてはいけないComponentを追加します
+func (q *Query3[A, B, C]) Without(ids ...ComponentID) *Query3[A, B, C] {
+	q.filter = q.filter.Without(ids...)
+	return q
+}
+
+// Added : Sinceで指定したTickより後に追加されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query3[A, B, C]) Added(ids ...ComponentID) *Query3[A, B, C] {
+	q.filter = q.filter.Added(ids...)
+	return q
+}
+
+// Changed : Sinceで指定したTickより後に変更されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query3[A, B, C]) Changed(ids ...ComponentID) *Query3[A, B, C] {
+	q.filter = q.filter.Changed(ids...)
+	return q
+}
+
+// Since : Added, Changedの判定の基準になるTickを設定します
+func (q *Query3[A, B, C]) Since(tick uint32) *Query3[A, B, C] {
+	q.filter = q.filter.Since(tick)
+	return q
+}
+
+// Filter : Queryが利用するFilterを取得します
+func (q *Query3[A, B, C]) Filter() Filter {
+	return q.filter
+}
+
+// Iter : Queryを走査するイテレータを取得します
+func (q *Query3[A, B, C]) Iter() Query3Iter[A, B, C] {
+	return Query3Iter[A, B, C]{
+		query: q.world.Query(q.filter),
+		ids:   q.ids,
+	}
+}
+
+// All : range-over-funcで走査するためのiter.Seq2を取得します
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します. Componentを変更する場合はAllMutを利用してください
+func (q *Query3[A, B, C]) All() iter.Seq2[Entity, Row3[A, B, C]] {
+	return q.all(false)
+}
+
+// AllMut : 変更する目的でrange-over-funcで走査するためのiter.Seq2を取得します
+// Allと異なり、走査したEntityの全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query3[A, B, C]) AllMut() iter.Seq2[Entity, Row3[A, B, C]] {
+	return q.all(true)
+}
+
+// all : range-over-funcで走査するためのiter.Seq2を取得します. mutがtrueの場合は変更を記録します
+func (q *Query3[A, B, C]) all(mut bool) iter.Seq2[Entity, Row3[A, B, C]] {
+	return func(yield func(Entity, Row3[A, B, C]) bool) {
+		it := q.Iter()
+		defer it.Close()
+		for it.Next() {
+			if mut {
+				it.markChanged()
+			}
+			entity, a, b, c := it.Get()
+			if !yield(entity, Row3[A, B, C]{A: a, B: b, C: c}) {
+				return
+			}
+		}
+	}
+}
+
+// Row3 : Query3.Allで走査する際の1Entity分のComponent
+type Row3[A, B, C any] struct {
+	A *A
+	B *B
+	C *C
+}
+
+// Chunks : Archetype単位で、EntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// 1件ずつ走査するよりも高速なため、大量のEntityを処理する場合に利用してください.
+// Added, ChangedはEntity単位の条件のためArchetype単位では判定できず、指定している場合はErrFilteredChunksでpanicします.
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します.
+// Componentを変更する場合はChunksMutを利用してください
+func (q *Query3[A, B, C]) Chunks() iter.Seq[Chunk3[A, B, C]] {
+	return q.chunks(false)
+}
+
+// ChunksMut : 変更する目的で、Archetype単位でEntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// Chunksと異なり、取得したChunkに含まれる全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query3[A, B, C]) ChunksMut() iter.Seq[Chunk3[A, B, C]] {
+	return q.chunks(true)
+}
+
+// chunks : Archetype単位で走査するためのiter.Seqを取得します. mutがtrueの場合は変更を記録します
+func (q *Query3[A, B, C]) chunks(mut bool) iter.Seq[Chunk3[A, B, C]] {
+	if q.filter.filtered() {
+		panic(ErrFilteredChunks)
+	}
+	return func(yield func(Chunk3[A, B, C]) bool) {
+		query := q.world.Query(q.filter)
+		defer query.Close()
+		for query.nextArchetype() {
+			a := query.archetype
+			if mut {
+				tick := q.world.Tick()
+				for _, id := range q.ids {
+					a.Column(id).MarkAllChanged(tick)
+				}
+			}
+			chunk := Chunk3[A, B, C]{
+				Entities: a.entities[:len(a.entities):len(a.entities)],
+				A:        columnSlice[A](a.Column(q.ids[0])),
+				B:        columnSlice[B](a.Column(q.ids[1])),
+				C:        columnSlice[C](a.Column(q.ids[2])),
+			}
+			if !yield(chunk) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk3 : Query3.Chunksで走査する際の1Archetype分のデータ
+// 各sliceの長さはEntitiesと一致し、同じIndexの要素が同じEntityのComponentを表します
+type Chunk3[A, B, C any] struct {
+	Entities []Entity
+	A        []A
+	B        []B
+	C        []C
+}
+
+// Query3Iter : Query3を走査するイテレータ
+type Query3Iter[A, B, C any] struct {
+	query     Query
+	ids       [3]ComponentID
+	archetype *archetype
+	columns   [3]*column
+}
+
+// Next : 次のEntityへ進みます. 走査が終了した場合はfalseを返します
+func (it *Query3Iter[A, B, C]) Next() bool {
+	if !it.query.Next() {
+		return false
+	}
+	// Archetypeが切り替わった場合のみcolumnを解決し直す
+	if it.query.archetype != it.archetype {
+		it.archetype = it.query.archetype
+		for i := range it.ids {
+			it.columns[i] = it.archetype.Column(it.ids[i])
+		}
+	}
+	return true
+}
+
+// Close : 走査を終了し、Worldのロックを解除します
+// 最後まで走査した場合は自動的に呼び出されるため、途中で走査を抜ける場合のみ呼び出してください
+func (it *Query3Iter[A, B, C]) Close() {
+	it.query.Close()
+}
+
+// Entity : 走査中のEntityを取得します
+func (it *Query3Iter[A, B, C]) Entity() Entity {
+	return it.query.Entity()
+}
+
+// Get : 走査中のEntityとComponentを取得します
+// 変更は記録されないため、Componentを変更する場合はGetMutを利用してください
+func (it *Query3Iter[A, B, C]) Get() (Entity, *A, *B, *C) {
+	index := it.query.index
+	return it.archetype.GetEntity(index), (*A)(it.columns[0].Get(index)), (*B)(it.columns[1].Get(index)), (*C)(it.columns[2].Get(index))
+}
+
+// GetMut : 変更する目的で走査中のEntityとComponentを取得します
+// Getと異なり、全てのComponentが現在のTickで変更されたものとして記録されます
+func (it *Query3Iter[A, B, C]) GetMut() (Entity, *A, *B, *C) {
+	it.markChanged()
+	return it.Get()
+}
+
+// markChanged : 走査中のEntityの全てのComponentを、現在のTickで変更されたものとして記録します
+func (it *Query3Iter[A, B, C]) markChanged() {
+	tick := it.query.world.Tick()
+	for _, c := range it.columns {
+		c.MarkChanged(it.query.index, tick)
+	}
+}
+
+// NewQuery4 : 4種類のComponentを持つEntityを型付きで走査するQuery4を生成します
+// 型がWorldに登録されていない場合はErrUnregisteredComponentでpanicします
+func NewQuery4[A, B, C, D any](w *World) *Query4[A, B, C, D] {
+	q := &Query4[A, B, C, D]{world: w}
+	q.ids[0] = NewField[A](w).ID()
+	q.ids[1] = NewField[B](w).ID()
+	q.ids[2] = NewField[C](w).ID()
+	q.ids[3] = NewField[D](w).ID()
+	q.filter = NewFilter(q.ids[:]...)
+	return q
+}
+
+// Query4 : 4種類のComponentを持つEntityを型付きで走査するQuery
+type Query4[A, B, C, D any] struct {
+	world  *World
+	ids    [4]ComponentID
+	filter Filter
+}
+
+// With : 持っている必要があるComponentを追加します
+func (q *Query4[A, B, C, D]) With(ids ...ComponentID) *Query4[A, B, C, D] {
+	q.filter = q.filter.With(ids...)
+	return q
+}
+
+// Without : 持っていてはいけないComponentを追加します
+func (q *Query4[A, B, C, D]) Without(ids ...ComponentID) *Query4[A, B, C, D] {
+	q.filter = q.filter.Without(ids...)
+	return q
+}
+
+// Added : Sinceで指定したTickより後に追加されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query4[A, B, C, D]) Added(ids ...ComponentID) *Query4[A, B, C, D] {
+	q.filter = q.filter.Added(ids...)
+	return q
+}
+
+// Changed : Sinceで指定したTickより後に変更されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query4[A, B, C, D]) Changed(ids ...ComponentID) *Query4[A, B, C, D] {
+	q.filter = q.filter.Changed(ids...)
+	return q
+}
+
+// Since : Added, Changedの判定の基準になるTickを設定します
+func (q *Query4[A, B, C, D]) Since(tick uint32) *Query4[A, B, C, D] {
+	q.filter = q.filter.Since(tick)
+	return q
+}
+
+// Filter : Queryが利用するFilterを取得します
+func (q *Query4[A, B, C, D]) Filter() Filter {
+	return q.filter
+}
+
+// Iter : Queryを走査するイテレータを取得します
+func (q *Query4[A, B, C, D]) Iter() Query4Iter[A, B, C, D] {
+	return Query4Iter[A, B, C, D]{
+		query: q.world.Query(q.filter),
+		ids:   q.ids,
+	}
+}
+
+// All : range-over-funcで走査するためのiter.Seq2を取得します
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します. Componentを変更する場合はAllMutを利用してください
+func (q *Query4[A, B, C, D]) All() iter.Seq2[Entity, Row4[A, B, C, D]] {
+	return q.all(false)
+}
+
+// AllMut : 変更する目的でrange-over-funcで走査するためのiter.Seq2を取得します
+// Allと異なり、走査したEntityの全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query4[A, B, C, D]) AllMut() iter.Seq2[Entity, Row4[A, B, C, D]] {
+	return q.all(true)
+}
+
+// all : range-over-funcで走査するためのiter.Seq2を取得します. mutがtrueの場合は変更を記録します
+func (q *Query4[A, B, C, D]) all(mut bool) iter.Seq2[Entity, Row4[A, B, C, D]] {
+	return func(yield func(Entity, Row4[A, B, C, D]) bool) {
+		it := q.Iter()
+		defer it.Close()
+		for it.Next() {
+			if mut {
+				it.markChanged()
+			}
+			entity, a, b, c, d := it.Get()
+			if !yield(entity, Row4[A, B, C, D]{A: a, B: b, C: c, D: d}) {
+				return
+			}
+		}
+	}
+}
+
+// Row4 : Query4.Allで走査する際の1Entity分のComponent
+type Row4[A, B, C, D any] struct {
+	A *A
+	B *B
+	C *C
+	D *D
+}
+
+// Chunks : Archetype単位で、EntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// 1件ずつ走査するよりも高速なため、大量のEntityを処理する場合に利用してください.
+// Added, ChangedはEntity単位の条件のためArchetype単位では判定できず、指定している場合はErrFilteredChunksでpanicします.
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します.
+// Componentを変更する場合はChunksMutを利用してください
+func (q *Query4[A, B, C, D]) Chunks() iter.Seq[Chunk4[A, B, C, D]] {
+	return q.chunks(false)
+}
+
+// ChunksMut : 変更する目的で、Archetype単位でEntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// Chunksと異なり、取得したChunkに含まれる全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query4[A, B, C, D]) ChunksMut() iter.Seq[Chunk4[A, B, C, D]] {
+	return q.chunks(true)
+}
+
+// chunks : Archetype単位で走査するためのiter.Seqを取得します. mutがtrueの場合は変更を記録します
+func (q *Query4[A, B, C, D]) chunks(mut bool) iter.Seq[Chunk4[A, B, C, D]] {
+	if q.filter.filtered() {
+		panic(ErrFilteredChunks)
+	}
+	return func(yield func(Chunk4[A, B, C, D]) bool) {
+		query := q.world.Query(q.filter)
+		defer query.Close()
+		for query.nextArchetype() {
+			a := query.archetype
+			if mut {
+				tick := q.world.Tick()
+				for _, id := range q.ids {
+					a.Column(id).MarkAllChanged(tick)
+				}
+			}
+			chunk := Chunk4[A, B, C, D]{
+				Entities: a.entities[:len(a.entities):len(a.entities)],
+				A:        columnSlice[A](a.Column(q.ids[0])),
+				B:        columnSlice[B](a.Column(q.ids[1])),
+				C:        columnSlice[C](a.Column(q.ids[2])),
+				D:        columnSlice[D](a.Column(q.ids[3])),
+			}
+			if !yield(chunk) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk4 : Query4.Chunksで走査する際の1Archetype分のデータ
+// 各sliceの長さはEntitiesと一致し、同じIndexの要素が同じEntityのComponentを表します
+type Chunk4[A, B, C, D any] struct {
+	Entities []Entity
+	A        []A
+	B        []B
+	C        []C
+	D        []D
+}
+
+// Query4Iter : Query4を走査するイテレータ
+type Query4Iter[A, B, C, D any] struct {
+	query     Query
+	ids       [4]ComponentID
+	archetype *archetype
+	columns   [4]*column
+}
+
+// Next : 次のEntityへ進みます. 走査が終了した場合はfalseを返します
+func (it *Query4Iter[A, B, C, D]) Next() bool {
+	if !it.query.Next() {
+		return false
+	}
+	// Archetypeが切り替わった場合のみcolumnを解決し直す
+	if it.query.archetype != it.archetype {
+		it.archetype = it.query.archetype
+		for i := range it.ids {
+			it.columns[i] = it.archetype.Column(it.ids[i])
+		}
+	}
+	return true
+}
+
+// Close : 走査を終了し、Worldのロックを解除します
+// 最後まで走査した場合は自動的に呼び出されるため、途中で走査を抜ける場合のみ呼び出してください
+func (it *Query4Iter[A, B, C, D]) Close() {
+	it.query.Close()
+}
+
+// Entity : 走査中のEntityを取得します
+func (it *Query4Iter[A, B, C, D]) Entity() Entity {
+	return it.query.Entity()
+}
+
+// Get : 走査中のEntityとComponentを取得します
+// 変更は記録されないため、Componentを変更する場合はGetMutを利用してください
+func (it *Query4Iter[A, B, C, D]) Get() (Entity, *A, *B, *C, *D) {
+	index := it.query.index
+	return it.archetype.GetEntity(index), (*A)(it.columns[0].Get(index)), (*B)(it.columns[1].Get(index)), (*C)(it.columns[2].Get(index)), (*D)(it.columns[3].Get(index))
+}
+
+// GetMut : 変更する目的で走査中のEntityとComponentを取得します
+// Getと異なり、全てのComponentが現在のTickで変更されたものとして記録されます
+func (it *Query4Iter[A, B, C, D]) GetMut() (Entity, *A, *B, *C, *D) {
+	it.markChanged()
+	return it.Get()
+}
+
+// markChanged : 走査中のEntityの全てのComponentを、現在のTickで変更されたものとして記録します
+func (it *Query4Iter[A, B, C, D]) markChanged() {
+	tick := it.query.world.Tick()
+	for _, c := range it.columns {
+		c.MarkChanged(it.query.index, tick)
+	}
+}
+
+// NewQuery5 : 5種類のComponentを持つEntityを型付きで走査するQuery5を生成します
+// 型がWorldに登録されていない場合はErrUnregisteredComponentでpanicします
+func NewQuery5[A, B, C, D, E any](w *World) *Query5[A, B, C, D, E] {
+	q := &Query5[A, B, C, D, E]{world: w}
+	q.ids[0] = NewField[A](w).ID()
+	q.ids[1] = NewField[B](w).ID()
+	q.ids[2] = NewField[C](w).ID()
+	q.ids[3] = NewField[D](w).ID()
+	q.ids[4] = NewField[E](w).ID()
+	q.filter = NewFilter(q.ids[:]...)
+	return q
+}
+
+// Query5 : 5種類のComponentを持つEntityを型付きで走査するQuery
+type Query5[A, B, C, D, E any] struct {
+	world  *World
+	ids    [5]ComponentID
+	filter Filter
+}
+
+// With : 持っている必要があるComponentを追加します
+func (q *Query5[A, B, C, D, E]) With(ids ...ComponentID) *Query5[A, B, C, D, E] {
+	q.filter = q.filter.With(ids...)
+	return q
+}
+
+// Without : 持っていてはいけないComponentを追加します
+func (q *Query5[A, B, C, D, E]) Without(ids ...ComponentID) *Query5[A, B, C, D, E] {
+	q.filter = q.filter.Without(ids...)
+	return q
+}
+
+// Added : Sinceで指定したTickより後に追加されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query5[A, B, C, D, E]) Added(ids ...ComponentID) *Query5[A, B, C, D, E] {
+	q.filter = q.filter.Added(ids...)
+	return q
+}
+
+// Changed : Sinceで指定したTickより後に変更されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query5[A, B, C, D, E]) Changed(ids ...ComponentID) *Query5[A, B, C, D, E] {
+	q.filter = q.filter.Changed(ids...)
+	return q
+}
+
+// Since : Added, Changedの判定の基準になるTickを設定します
+func (q *Query5[A, B, C, D, E]) Since(tick uint32) *Query5[A, B, C, D, E] {
+	q.filter = q.filter.Since(tick)
+	return q
+}
+
+// Filter : Queryが利用するFilterを取得します
+func (q *Query5[A, B, C, D, E]) Filter() Filter {
+	return q.filter
+}
+
+// Iter : Queryを走査するイテレータを取得します
+func (q *Query5[A, B, C, D, E]) Iter() Query5Iter[A, B, C, D, E] {
+	return Query5Iter[A, B, C, D, E]{
+		query: q.world.Query(q.filter),
+		ids:   q.ids,
+	}
+}
+
+// All : range-over-funcで走査するためのiter.Seq2を取得します
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します. Componentを変更する場合はAllMutを利用してください
+func (q *Query5[A, B, C, D, E]) All() iter.Seq2[Entity, Row5[A, B, C, D, E]] {
+	return q.all(false)
+}
+
+// AllMut : 変更する目的でrange-over-funcで走査するためのiter.Seq2を取得します
+// Allと異なり、走査したEntityの全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query5[A, B, C, D, E]) AllMut() iter.Seq2[Entity, Row5[A, B, C, D, E]] {
+	return q.all(true)
+}
+
+// all : range-over-funcで走査するためのiter.Seq2を取得します. mutがtrueの場合は変更を記録します
+func (q *Query5[A, B, C, D, E]) all(mut bool) iter.Seq2[Entity, Row5[A, B, C, D, E]] {
+	return func(yield func(Entity, Row5[A, B, C, D, E]) bool) {
+		it := q.Iter()
+		defer it.Close()
+		for it.Next() {
+			if mut {
+				it.markChanged()
+			}
+			entity, a, b, c, d, e := it.Get()
+			if !yield(entity, Row5[A, B, C, D, E]{A: a, B: b, C: c, D: d, E: e}) {
+				return
+			}
+		}
+	}
+}
+
+// Row5 : Query5.Allで走査する際の1Entity分のComponent
+type Row5[A, B, C, D, E any] struct {
+	A *A
+	B *B
+	C *C
+	D *D
+	E *E
+}
+
+// Chunks : Archetype単位で、EntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// 1件ずつ走査するよりも高速なため、大量のEntityを処理する場合に利用してください.
+// Added, ChangedはEntity単位の条件のためArchetype単位では判定できず、指定している場合はErrFilteredChunksでpanicします.
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します.
+// Componentを変更する場合はChunksMutを利用してください
+func (q *Query5[A, B, C, D, E]) Chunks() iter.Seq[Chunk5[A, B, C, D, E]] {
+	return q.chunks(false)
+}
+
+// ChunksMut : 変更する目的で、Archetype単位でEntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// Chunksと異なり、取得したChunkに含まれる全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query5[A, B, C, D, E]) ChunksMut() iter.Seq[Chunk5[A, B, C, D, E]] {
+	return q.chunks(true)
+}
+
+// chunks : Archetype単位で走査するためのiter.Seqを取得します. mutがtrueの場合は変更を記録します
+func (q *Query5[A, B, C, D, E]) chunks(mut bool) iter.Seq[Chunk5[A, B, C, D, E]] {
+	if q.filter.filtered() {
+		panic(ErrFilteredChunks)
+	}
+	return func(yield func(Chunk5[A, B, C, D, E]) bool) {
+		query := q.world.Query(q.filter)
+		defer query.Close()
+		for query.nextArchetype() {
+			a := query.archetype
+			if mut {
+				tick := q.world.Tick()
+				for _, id := range q.ids {
+					a.Column(id).MarkAllChanged(tick)
+				}
+			}
+			chunk := Chunk5[A, B, C, D, E]{
+				Entities: a.entities[:len(a.entities):len(a.entities)],
+				A:        columnSlice[A](a.Column(q.ids[0])),
+				B:        columnSlice[B](a.Column(q.ids[1])),
+				C:        columnSlice[C](a.Column(q.ids[2])),
+				D:        columnSlice[D](a.Column(q.ids[3])),
+				E:        columnSlice[E](a.Column(q.ids[4])),
+			}
+			if !yield(chunk) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk5 : Query5.Chunksで走査する際の1Archetype分のデータ
+// 各sliceの長さはEntitiesと一致し、同じIndexの要素が同じEntityのComponentを表します
+type Chunk5[A, B, C, D, E any] struct {
+	Entities []Entity
+	A        []A
+	B        []B
+	C        []C
+	D        []D
+	E        []E
+}
+
+// Query5Iter : Query5を走査するイテレータ
+type Query5Iter[A, B, C, D, E any] struct {
+	query     Query
+	ids       [5]ComponentID
+	archetype *archetype
+	columns   [5]*column
+}
+
+// Next : 次のEntityへ進みます. 走査が終了した場合はfalseを返します
+func (it *Query5Iter[A, B, C, D, E]) Next() bool {
+	if !it.query.Next() {
+		return false
+	}
+	// Archetypeが切り替わった場合のみcolumnを解決し直す
+	if it.query.archetype != it.archetype {
+		it.archetype = it.query.archetype
+		for i := range it.ids {
+			it.columns[i] = it.archetype.Column(it.ids[i])
+		}
+	}
+	return true
+}
+
+// Close : 走査を終了し、Worldのロックを解除します
+// 最後まで走査した場合は自動的に呼び出されるため、途中で走査を抜ける場合のみ呼び出してください
+func (it *Query5Iter[A, B, C, D, E]) Close() {
+	it.query.Close()
+}
+
+// Entity : 走査中のEntityを取得します
+func (it *Query5Iter[A, B, C, D, E]) Entity() Entity {
+	return it.query.Entity()
+}
+
+// Get : 走査中のEntityとComponentを取得します
+// 変更は記録されないため、Componentを変更する場合はGetMutを利用してください
+func (it *Query5Iter[A, B, C, D, E]) Get() (Entity, *A, *B, *C, *D, *E) {
+	index := it.query.index
+	return it.archetype.GetEntity(index), (*A)(it.columns[0].Get(index)), (*B)(it.columns[1].Get(index)), (*C)(it.columns[2].Get(index)), (*D)(it.columns[3].Get(index)), (*E)(it.columns[4].Get(index))
+}
+
+// GetMut : 変更する目的で走査中のEntityとComponentを取得します
+// Getと異なり、全てのComponentが現在のTickで変更されたものとして記録されます
+func (it *Query5Iter[A, B, C, D, E]) GetMut() (Entity, *A, *B, *C, *D, *E) {
+	it.markChanged()
+	return it.Get()
+}
+
+// markChanged : 走査中のEntityの全てのComponentを、現在のTickで変更されたものとして記録します
+func (it *Query5Iter[A, B, C, D, E]) markChanged() {
+	tick := it.query.world.Tick()
+	for _, c := range it.columns {
+		c.MarkChanged(it.query.index, tick)
+	}
+}
+
+// NewQuery6 : 6種類のComponentを持つEntityを型付きで走査するQuery6を生成します
+// 型がWorldに登録されていない場合はErrUnregisteredComponentでpanicします
+func NewQuery6[A, B, C, D, E, F any](w *World) *Query6[A, B, C, D, E, F] {
+	q := &Query6[A, B, C, D, E, F]{world: w}
+	q.ids[0] = NewField[A](w).ID()
+	q.ids[1] = NewField[B](w).ID()
+	q.ids[2] = NewField[C](w).ID()
+	q.ids[3] = NewField[D](w).ID()
+	q.ids[4] = NewField[E](w).ID()
+	q.ids[5] = NewField[F](w).ID()
+	q.filter = NewFilter(q.ids[:]...)
+	return q
+}
+
+// Query6 : 6種類のComponentを持つEntityを型付きで走査するQuery
+type Query6[A, B, C, D, E, F any] struct {
+	world  *World
+	ids    [6]ComponentID
+	filter Filter
+}
+
+// With : 持っている必要があるComponentを追加します
+func (q *Query6[A, B, C, D, E, F]) With(ids ...ComponentID) *Query6[A, B, C, D, E, F] {
+	q.filter = q.filter.With(ids...)
+	return q
+}
+
+// Without : 持っていてはいけないComponentを追加します
+func (q *Query6[A, B, C, D, E, F]) Without(ids ...ComponentID) *Query6[A, B, C, D, E, F] {
+	q.filter = q.filter.Without(ids...)
+	return q
+}
+
+// Added : Sinceで指定したTickより後に追加されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query6[A, B, C, D, E, F]) Added(ids ...ComponentID) *Query6[A, B, C, D, E, F] {
+	q.filter = q.filter.Added(ids...)
+	return q
+}
+
+// Changed : Sinceで指定したTickより後に変更されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query6[A, B, C, D, E, F]) Changed(ids ...ComponentID) *Query6[A, B, C, D, E, F] {
+	q.filter = q.filter.Changed(ids...)
+	return q
+}
+
+// Since : Added, Changedの判定の基準になるTickを設定します
+func (q *Query6[A, B, C, D, E, F]) Since(tick uint32) *Query6[A, B, C, D, E, F] {
+	q.filter = q.filter.Since(tick)
+	return q
+}
+
+// Filter : Queryが利用するFilterを取得します
+func (q *Query6[A, B, C, D, E, F]) Filter() Filter {
+	return q.filter
+}
+
+// Iter : Queryを走査するイテレータを取得します
+func (q *Query6[A, B, C, D, E, F]) Iter() Query6Iter[A, B, C, D, E, F] {
+	return Query6Iter[A, B, C, D, E, F]{
+		query: q.world.Query(q.filter),
+		ids:   q.ids,
+	}
+}
+
+// All : range-over-funcで走査するためのiter.Seq2を取得します
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します. Componentを変更する場合はAllMutを利用してください
+func (q *Query6[A, B, C, D, E, F]) All() iter.Seq2[Entity, Row6[A, B, C, D, E, F]] {
+	return q.all(false)
+}
+
+// AllMut : 変更する目的でrange-over-funcで走査するためのiter.Seq2を取得します
+// Allと異なり、走査したEntityの全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query6[A, B, C, D, E, F]) AllMut() iter.Seq2[Entity, Row6[A, B, C, D, E, F]] {
+	return q.all(true)
+}
+
+// all : range-over-funcで走査するためのiter.Seq2を取得します. mutがtrueの場合は変更を記録します
+func (q *Query6[A, B, C, D, E, F]) all(mut bool) iter.Seq2[Entity, Row6[A, B, C, D, E, F]] {
+	return func(yield func(Entity, Row6[A, B, C, D, E, F]) bool) {
+		it := q.Iter()
+		defer it.Close()
+		for it.Next() {
+			if mut {
+				it.markChanged()
+			}
+			entity, a, b, c, d, e, f := it.Get()
+			if !yield(entity, Row6[A, B, C, D, E, F]{A: a, B: b, C: c, D: d, E: e, F: f}) {
+				return
+			}
+		}
+	}
+}
+
+// Row6 : Query6.Allで走査する際の1Entity分のComponent
+type Row6[A, B, C, D, E, F any] struct {
+	A *A
+	B *B
+	C *C
+	D *D
+	E *E
+	F *F
+}
+
+// Chunks : Archetype単位で、EntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// 1件ずつ走査するよりも高速なため、大量のEntityを処理する場合に利用してください.
+// Added, ChangedはEntity単位の条件のためArchetype単位では判定できず、指定している場合はErrFilteredChunksでpanicします.
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します.
+// Componentを変更する場合はChunksMutを利用してください
+func (q *Query6[A, B, C, D, E, F]) Chunks() iter.Seq[Chunk6[A, B, C, D, E, F]] {
+	return q.chunks(false)
+}
+
+// ChunksMut : 変更する目的で、Archetype単位でEntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// Chunksと異なり、取得したChunkに含まれる全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query6[A, B, C, D, E, F]) ChunksMut() iter.Seq[Chunk6[A, B, C, D, E, F]] {
+	return q.chunks(true)
+}
+
+// chunks : Archetype単位で走査するためのiter.Seqを取得します. mutがtrueの場合は変更を記録します
+func (q *Query6[A, B, C, D, E, F]) chunks(mut bool) iter.Seq[Chunk6[A, B, C, D, E, F]] {
+	if q.filter.filtered() {
+		panic(ErrFilteredChunks)
+	}
+	return func(yield func(Chunk6[A, B, C, D, E, F]) bool) {
+		query := q.world.Query(q.filter)
+		defer query.Close()
+		for query.nextArchetype() {
+			a := query.archetype
+			if mut {
+				tick := q.world.Tick()
+				for _, id := range q.ids {
+					a.Column(id).MarkAllChanged(tick)
+				}
+			}
+			chunk := Chunk6[A, B, C, D, E, F]{
+				Entities: a.entities[:len(a.entities):len(a.entities)],
+				A:        columnSlice[A](a.Column(q.ids[0])),
+				B:        columnSlice[B](a.Column(q.ids[1])),
+				C:        columnSlice[C](a.Column(q.ids[2])),
+				D:        columnSlice[D](a.Column(q.ids[3])),
+				E:        columnSlice[E](a.Column(q.ids[4])),
+				F:        columnSlice[F](a.Column(q.ids[5])),
+			}
+			if !yield(chunk) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk6 : Query6.Chunksで走査する際の1Archetype分のデータ
+// 各sliceの長さはEntitiesと一致し、同じIndexの要素が同じEntityのComponentを表します
+type Chunk6[A, B, C, D, E, F any] struct {
+	Entities []Entity
+	A        []A
+	B        []B
+	C        []C
+	D        []D
+	E        []E
+	F        []F
+}
+
+// Query6Iter : Query6を走査するイテレータ
+type Query6Iter[A, B, C, D, E, F any] struct {
+	query     Query
+	ids       [6]ComponentID
+	archetype *archetype
+	columns   [6]*column
+}
+
+// Next : 次のEntityへ進みます. 走査が終了した場合はfalseを返します
+func (it *Query6Iter[A, B, C, D, E, F]) Next() bool {
+	if !it.query.Next() {
+		return false
+	}
+	// Archetypeが切り替わった場合のみcolumnを解決し直す
+	if it.query.archetype != it.archetype {
+		it.archetype = it.query.archetype
+		for i := range it.ids {
+			it.columns[i] = it.archetype.Column(it.ids[i])
+		}
+	}
+	return true
+}
+
+// Close : 走査を終了し、Worldのロックを解除します
+// 最後まで走査した場合は自動的に呼び出されるため、途中で走査を抜ける場合のみ呼び出してください
+func (it *Query6Iter[A, B, C, D, E, F]) Close() {
+	it.query.Close()
+}
+
+// Entity : 走査中のEntityを取得します
+func (it *Query6Iter[A, B, C, D, E, F]) Entity() Entity {
+	return it.query.Entity()
+}
+
+// Get : 走査中のEntityとComponentを取得します
+// 変更は記録されないため、Componentを変更する場合はGetMutを利用してください
+func (it *Query6Iter[A, B, C, D, E, F]) Get() (Entity, *A, *B, *C, *D, *E, *F) {
+	index := it.query.index
+	return it.archetype.GetEntity(index), (*A)(it.columns[0].Get(index)), (*B)(it.columns[1].Get(index)), (*C)(it.columns[2].Get(index)), (*D)(it.columns[3].Get(index)), (*E)(it.columns[4].Get(index)), (*F)(it.columns[5].Get(index))
+}
+
+// GetMut : 変更する目的で走査中のEntityとComponentを取得します
+// Getと異なり、全てのComponentが現在のTickで変更されたものとして記録されます
+func (it *Query6Iter[A, B, C, D, E, F]) GetMut() (Entity, *A, *B, *C, *D, *E, *F) {
+	it.markChanged()
+	return it.Get()
+}
+
+// markChanged : 走査中のEntityの全てのComponentを、現在のTickで変更されたものとして記録します
+func (it *Query6Iter[A, B, C, D, E, F]) markChanged() {
+	tick := it.query.world.Tick()
+	for _, c := range it.columns {
+		c.MarkChanged(it.query.index, tick)
+	}
+}
+
+// NewQuery7 : 7種類のComponentを持つEntityを型付きで走査するQuery7を生成します
+// 型がWorldに登録されていない場合はErrUnregisteredComponentでpanicします
+func NewQuery7[A, B, C, D, E, F, G any](w *World) *Query7[A, B, C, D, E, F, G] {
+	q := &Query7[A, B, C, D, E, F, G]{world: w}
+	q.ids[0] = NewField[A](w).ID()
+	q.ids[1] = NewField[B](w).ID()
+	q.ids[2] = NewField[C](w).ID()
+	q.ids[3] = NewField[D](w).ID()
+	q.ids[4] = NewField[E](w).ID()
+	q.ids[5] = NewField[F](w).ID()
+	q.ids[6] = NewField[G](w).ID()
+	q.filter = NewFilter(q.ids[:]...)
+	return q
+}
+
+// Query7 : 7種類のComponentを持つEntityを型付きで走査するQuery
+type Query7[A, B, C, D, E, F, G any] struct {
+	world  *World
+	ids    [7]ComponentID
+	filter Filter
+}
+
+// With : 持っている必要があるComponentを追加します
+func (q *Query7[A, B, C, D, E, F, G]) With(ids ...ComponentID) *Query7[A, B, C, D, E, F, G] {
+	q.filter = q.filter.With(ids...)
+	return q
+}
+
+// Without : 持っていてはいけないComponentを追加します
+func (q *Query7[A, B, C, D, E, F, G]) Without(ids ...ComponentID) *Query7[A, B, C, D, E, F, G] {
+	q.filter = q.filter.Without(ids...)
+	return q
+}
+
+// Added : Sinceで指定したTickより後に追加されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query7[A, B, C, D, E, F, G]) Added(ids ...ComponentID) *Query7[A, B, C, D, E, F, G] {
+	q.filter = q.filter.Added(ids...)
+	return q
+}
+
+// Changed : Sinceで指定したTickより後に変更されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query7[A, B, C, D, E, F, G]) Changed(ids ...ComponentID) *Query7[A, B, C, D, E, F, G] {
+	q.filter = q.filter.Changed(ids...)
+	return q
+}
+
+// Since : Added, Changedの判定の基準になるTickを設定します
+func (q *Query7[A, B, C, D, E, F, G]) Since(tick uint32) *Query7[A, B, C, D, E, F, G] {
+	q.filter = q.filter.Since(tick)
+	return q
+}
+
+// Filter : Queryが利用するFilterを取得します
+func (q *Query7[A, B, C, D, E, F, G]) Filter() Filter {
+	return q.filter
+}
+
+// Iter : Queryを走査するイテレータを取得します
+func (q *Query7[A, B, C, D, E, F, G]) Iter() Query7Iter[A, B, C, D, E, F, G] {
+	return Query7Iter[A, B, C, D, E, F, G]{
+		query: q.world.Query(q.filter),
+		ids:   q.ids,
+	}
+}
+
+// All : range-over-funcで走査するためのiter.Seq2を取得します
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します. Componentを変更する場合はAllMutを利用してください
+func (q *Query7[A, B, C, D, E, F, G]) All() iter.Seq2[Entity, Row7[A, B, C, D, E, F, G]] {
+	return q.all(false)
+}
+
+// AllMut : 変更する目的でrange-over-funcで走査するためのiter.Seq2を取得します
+// Allと異なり、走査したEntityの全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query7[A, B, C, D, E, F, G]) AllMut() iter.Seq2[Entity, Row7[A, B, C, D, E, F, G]] {
+	return q.all(true)
+}
+
+// all : range-over-funcで走査するためのiter.Seq2を取得します. mutがtrueの場合は変更を記録します
+func (q *Query7[A, B, C, D, E, F, G]) all(mut bool) iter.Seq2[Entity, Row7[A, B, C, D, E, F, G]] {
+	return func(yield func(Entity, Row7[A, B, C, D, E, F, G]) bool) {
+		it := q.Iter()
+		defer it.Close()
+		for it.Next() {
+			if mut {
+				it.markChanged()
+			}
+			entity, a, b, c, d, e, f, g := it.Get()
+			if !yield(entity, Row7[A, B, C, D, E, F, G]{A: a, B: b, C: c, D: d, E: e, F: f, G: g}) {
+				return
+			}
+		}
+	}
+}
+
+// Row7 : Query7.Allで走査する際の1Entity分のComponent
+type Row7[A, B, C, D, E, F, G any] struct {
+	A *A
+	B *B
+	C *C
+	D *D
+	E *E
+	F *F
+	G *G
+}
+
+// Chunks : Archetype単位で、EntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// 1件ずつ走査するよりも高速なため、大量のEntityを処理する場合に利用してください.
+// Added, ChangedはEntity単位の条件のためArchetype単位では判定できず、指定している場合はErrFilteredChunksでpanicします.
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します.
+// Componentを変更する場合はChunksMutを利用してください
+func (q *Query7[A, B, C, D, E, F, G]) Chunks() iter.Seq[Chunk7[A, B, C, D, E, F, G]] {
+	return q.chunks(false)
+}
+
+// ChunksMut : 変更する目的で、Archetype単位でEntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// Chunksと異なり、取得したChunkに含まれる全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query7[A, B, C, D, E, F, G]) ChunksMut() iter.Seq[Chunk7[A, B, C, D, E, F, G]] {
+	return q.chunks(true)
+}
+
+// chunks : Archetype単位で走査するためのiter.Seqを取得します. mutがtrueの場合は変更を記録します
+func (q *Query7[A, B, C, D, E, F, G]) chunks(mut bool) iter.Seq[Chunk7[A, B, C, D, E, F, G]] {
+	if q.filter.filtered() {
+		panic(ErrFilteredChunks)
+	}
+	return func(yield func(Chunk7[A, B, C, D, E, F, G]) bool) {
+		query := q.world.Query(q.filter)
+		defer query.Close()
+		for query.nextArchetype() {
+			a := query.archetype
+			if mut {
+				tick := q.world.Tick()
+				for _, id := range q.ids {
+					a.Column(id).MarkAllChanged(tick)
+				}
+			}
+			chunk := Chunk7[A, B, C, D, E, F, G]{
+				Entities: a.entities[:len(a.entities):len(a.entities)],
+				A:        columnSlice[A](a.Column(q.ids[0])),
+				B:        columnSlice[B](a.Column(q.ids[1])),
+				C:        columnSlice[C](a.Column(q.ids[2])),
+				D:        columnSlice[D](a.Column(q.ids[3])),
+				E:        columnSlice[E](a.Column(q.ids[4])),
+				F:        columnSlice[F](a.Column(q.ids[5])),
+				G:        columnSlice[G](a.Column(q.ids[6])),
+			}
+			if !yield(chunk) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk7 : Query7.Chunksで走査する際の1Archetype分のデータ
+// 各sliceの長さはEntitiesと一致し、同じIndexの要素が同じEntityのComponentを表します
+type Chunk7[A, B, C, D, E, F, G any] struct {
+	Entities []Entity
+	A        []A
+	B        []B
+	C        []C
+	D        []D
+	E        []E
+	F        []F
+	G        []G
+}
+
+// Query7Iter : Query7を走査するイテレータ
+type Query7Iter[A, B, C, D, E, F, G any] struct {
+	query     Query
+	ids       [7]ComponentID
+	archetype *archetype
+	columns   [7]*column
+}
+
+// Next : 次のEntityへ進みます. 走査が終了した場合はfalseを返します
+func (it *Query7Iter[A, B, C, D, E, F, G]) Next() bool {
+	if !it.query.Next() {
+		return false
+	}
+	// Archetypeが切り替わった場合のみcolumnを解決し直す
+	if it.query.archetype != it.archetype {
+		it.archetype = it.query.archetype
+		for i := range it.ids {
+			it.columns[i] = it.archetype.Column(it.ids[i])
+		}
+	}
+	return true
+}
+
+// Close : 走査を終了し、Worldのロックを解除します
+// 最後まで走査した場合は自動的に呼び出されるため、途中で走査を抜ける場合のみ呼び出してください
+func (it *Query7Iter[A, B, C, D, E, F, G]) Close() {
+	it.query.Close()
+}
+
+// Entity : 走査中のEntityを取得します
+func (it *Query7Iter[A, B, C, D, E, F, G]) Entity() Entity {
+	return it.query.Entity()
+}
+
+// Get : 走査中のEntityとComponentを取得します
+// 変更は記録されないため、Componentを変更する場合はGetMutを利用してください
+func (it *Query7Iter[A, B, C, D, E, F, G]) Get() (Entity, *A, *B, *C, *D, *E, *F, *G) {
+	index := it.query.index
+	return it.archetype.GetEntity(index), (*A)(it.columns[0].Get(index)), (*B)(it.columns[1].Get(index)), (*C)(it.columns[2].Get(index)), (*D)(it.columns[3].Get(index)), (*E)(it.columns[4].Get(index)), (*F)(it.columns[5].Get(index)), (*G)(it.columns[6].Get(index))
+}
+
+// GetMut : 変更する目的で走査中のEntityとComponentを取得します
+// Getと異なり、全てのComponentが現在のTickで変更されたものとして記録されます
+func (it *Query7Iter[A, B, C, D, E, F, G]) GetMut() (Entity, *A, *B, *C, *D, *E, *F, *G) {
+	it.markChanged()
+	return it.Get()
+}
+
+// markChanged : 走査中のEntityの全てのComponentを、現在のTickで変更されたものとして記録します
+func (it *Query7Iter[A, B, C, D, E, F, G]) markChanged() {
+	tick := it.query.world.Tick()
+	for _, c := range it.columns {
+		c.MarkChanged(it.query.index, tick)
+	}
+}
+
+// NewQuery8 : 8種類のComponentを持つEntityを型付きで走査するQuery8を生成します
+// 型がWorldに登録されていない場合はErrUnregisteredComponentでpanicします
+func NewQuery8[A, B, C, D, E, F, G, H any](w *World) *Query8[A, B, C, D, E, F, G, H] {
+	q := &Query8[A, B, C, D, E, F, G, H]{world: w}
+	q.ids[0] = NewField[A](w).ID()
+	q.ids[1] = NewField[B](w).ID()
+	q.ids[2] = NewField[C](w).ID()
+	q.ids[3] = NewField[D](w).ID()
+	q.ids[4] = NewField[E](w).ID()
+	q.ids[5] = NewField[F](w).ID()
+	q.ids[6] = NewField[G](w).ID()
+	q.ids[7] = NewField[H](w).ID()
+	q.filter = NewFilter(q.ids[:]...)
+	return q
+}
+
+// Query8 : 8種類のComponentを持つEntityを型付きで走査するQuery
+type Query8[A, B, C, D, E, F, G, H any] struct {
+	world  *World
+	ids    [8]ComponentID
+	filter Filter
+}
+
+// With : 持っている必要があるComponentを追加します
+func (q *Query8[A, B, C, D, E, F, G, H]) With(ids ...ComponentID) *Query8[A, B, C, D, E, F, G, H] {
+	q.filter = q.filter.With(ids...)
+	return q
+}
+
+// Without : 持っていてはいけないComponentを追加します
+func (q *Query8[A, B, C, D, E, F, G, H]) Without(ids ...ComponentID) *Query8[A, B, C, D, E, F, G, H] {
+	q.filter = q.filter.Without(ids...)
+	return q
+}
+
+// Added : Sinceで指定したTickより後に追加されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query8[A, B, C, D, E, F, G, H]) Added(ids ...ComponentID) *Query8[A, B, C, D, E, F, G, H] {
+	q.filter = q.filter.Added(ids...)
+	return q
+}
+
+// Changed : Sinceで指定したTickより後に変更されている必要があるComponentを追加します
+// Entity単位の条件のため、指定した場合はChunks, ChunksMutを利用できません
+func (q *Query8[A, B, C, D, E, F, G, H]) Changed(ids ...ComponentID) *Query8[A, B, C, D, E, F, G, H] {
+	q.filter = q.filter.Changed(ids...)
+	return q
+}
+
+// Since : Added, Changedの判定の基準になるTickを設定します
+func (q *Query8[A, B, C, D, E, F, G, H]) Since(tick uint32) *Query8[A, B, C, D, E, F, G, H] {
+	q.filter = q.filter.Since(tick)
+	return q
+}
+
+// Filter : Queryが利用するFilterを取得します
+func (q *Query8[A, B, C, D, E, F, G, H]) Filter() Filter {
+	return q.filter
+}
+
+// Iter : Queryを走査するイテレータを取得します
+func (q *Query8[A, B, C, D, E, F, G, H]) Iter() Query8Iter[A, B, C, D, E, F, G, H] {
+	return Query8Iter[A, B, C, D, E, F, G, H]{
+		query: q.world.Query(q.filter),
+		ids:   q.ids,
+	}
+}
+
+// All : range-over-funcで走査するためのiter.Seq2を取得します
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します. Componentを変更する場合はAllMutを利用してください
+func (q *Query8[A, B, C, D, E, F, G, H]) All() iter.Seq2[Entity, Row8[A, B, C, D, E, F, G, H]] {
+	return q.all(false)
+}
+
+// AllMut : 変更する目的でrange-over-funcで走査するためのiter.Seq2を取得します
+// Allと異なり、走査したEntityの全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query8[A, B, C, D, E, F, G, H]) AllMut() iter.Seq2[Entity, Row8[A, B, C, D, E, F, G, H]] {
+	return q.all(true)
+}
+
+// all : range-over-funcで走査するためのiter.Seq2を取得します. mutがtrueの場合は変更を記録します
+func (q *Query8[A, B, C, D, E, F, G, H]) all(mut bool) iter.Seq2[Entity, Row8[A, B, C, D, E, F, G, H]] {
+	return func(yield func(Entity, Row8[A, B, C, D, E, F, G, H]) bool) {
+		it := q.Iter()
+		defer it.Close()
+		for it.Next() {
+			if mut {
+				it.markChanged()
+			}
+			entity, a, b, c, d, e, f, g, h := it.Get()
+			if !yield(entity, Row8[A, B, C, D, E, F, G, H]{A: a, B: b, C: c, D: d, E: e, F: f, G: g, H: h}) {
+				return
+			}
+		}
+	}
+}
+
+// Row8 : Query8.Allで走査する際の1Entity分のComponent
+type Row8[A, B, C, D, E, F, G, H any] struct {
+	A *A
+	B *B
+	C *C
+	D *D
+	E *E
+	F *F
+	G *G
+	H *H
+}
+
+// Chunks : Archetype単位で、EntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// 1件ずつ走査するよりも高速なため、大量のEntityを処理する場合に利用してください.
+// Added, ChangedはEntity単位の条件のためArchetype単位では判定できず、指定している場合はErrFilteredChunksでpanicします.
+// breakやpanicで走査を抜けた場合もWorldのロックを解除します.
+// Componentを変更する場合はChunksMutを利用してください
+func (q *Query8[A, B, C, D, E, F, G, H]) Chunks() iter.Seq[Chunk8[A, B, C, D, E, F, G, H]] {
+	return q.chunks(false)
+}
+
+// ChunksMut : 変更する目的で、Archetype単位でEntityとComponentの連続したsliceを走査するためのiter.Seqを取得します
+// Chunksと異なり、取得したChunkに含まれる全てのComponentが現在のTickで変更されたものとして記録されます
+func (q *Query8[A, B, C, D, E, F, G, H]) ChunksMut() iter.Seq[Chunk8[A, B, C, D, E, F, G, H]] {
+	return q.chunks(true)
+}
+
+// chunks : Archetype単位で走査するためのiter.Seqを取得します. mutがtrueの場合は変更を記録します
+func (q *Query8[A, B, C, D, E, F, G, H]) chunks(mut bool) iter.Seq[Chunk8[A, B, C, D, E, F, G, H]] {
+	if q.filter.filtered() {
+		panic(ErrFilteredChunks)
+	}
+	return func(yield func(Chunk8[A, B, C, D, E, F, G, H]) bool) {
+		query := q.world.Query(q.filter)
+		defer query.Close()
+		for query.nextArchetype() {
+			a := query.archetype
+			if mut {
+				tick := q.world.Tick()
+				for _, id := range q.ids {
+					a.Column(id).MarkAllChanged(tick)
+				}
+			}
+			chunk := Chunk8[A, B, C, D, E, F, G, H]{
+				Entities: a.entities[:len(a.entities):len(a.entities)],
+				A:        columnSlice[A](a.Column(q.ids[0])),
+				B:        columnSlice[B](a.Column(q.ids[1])),
+				C:        columnSlice[C](a.Column(q.ids[2])),
+				D:        columnSlice[D](a.Column(q.ids[3])),
+				E:        columnSlice[E](a.Column(q.ids[4])),
+				F:        columnSlice[F](a.Column(q.ids[5])),
+				G:        columnSlice[G](a.Column(q.ids[6])),
+				H:        columnSlice[H](a.Column(q.ids[7])),
+			}
+			if !yield(chunk) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk8 : Query8.Chunksで走査する際の1Archetype分のデータ
+// 各sliceの長さはEntitiesと一致し、同じIndexの要素が同じEntityのComponentを表します
+type Chunk8[A, B, C, D, E, F, G, H any] struct {
+	Entities []Entity
+	A        []A
+	B        []B
+	C        []C
+	D        []D
+	E        []E
+	F        []F
+	G        []G
+	H        []H
+}
+
+// Query8Iter : Query8を走査するイテレータ
+type Query8Iter[A, B, C, D, E, F, G, H any] struct {
+	query     Query
+	ids       [8]ComponentID
+	archetype *archetype
+	columns   [8]*column
+}
+
+// Next : 次のEntityへ進みます. 走査が終了した場合はfalseを返します
+func (it *Query8Iter[A, B, C, D, E, F, G, H]) Next() bool {
+	if !it.query.Next() {
+		return false
+	}
+	// Archetypeが切り替わった場合のみcolumnを解決し直す
+	if it.query.archetype != it.archetype {
+		it.archetype = it.query.archetype
+		for i := range it.ids {
+			it.columns[i] = it.archetype.Column(it.ids[i])
+		}
+	}
+	return true
+}
+
+// Close : 走査を終了し、Worldのロックを解除します
+// 最後まで走査した場合は自動的に呼び出されるため、途中で走査を抜ける場合のみ呼び出してください
+func (it *Query8Iter[A, B, C, D, E, F, G, H]) Close() {
+	it.query.Close()
+}
+
+// Entity : 走査中のEntityを取得します
+func (it *Query8Iter[A, B, C, D, E, F, G, H]) Entity() Entity {
+	return it.query.Entity()
+}
+
+// Get : 走査中のEntityとComponentを取得します
+// 変更は記録されないため、Componentを変更する場合はGetMutを利用してください
+func (it *Query8Iter[A, B, C, D, E, F, G, H]) Get() (Entity, *A, *B, *C, *D, *E, *F, *G, *H) {
+	index := it.query.index
+	return it.archetype.GetEntity(index), (*A)(it.columns[0].Get(index)), (*B)(it.columns[1].Get(index)), (*C)(it.columns[2].Get(index)), (*D)(it.columns[3].Get(index)), (*E)(it.columns[4].Get(index)), (*F)(it.columns[5].Get(index)), (*G)(it.columns[6].Get(index)), (*H)(it.columns[7].Get(index))
+}
+
+// GetMut : 変更する目的で走査中のEntityとComponentを取得します
+// Getと異なり、全てのComponentが現在のTickで変更されたものとして記録されます
+func (it *Query8Iter[A, B, C, D, E, F, G, H]) GetMut() (Entity, *A, *B, *C, *D, *E, *F, *G, *H) {
+	it.markChanged()
+	return it.Get()
+}
+
+// markChanged : 走査中のEntityの全てのComponentを、現在のTickで変更されたものとして記録します
+func (it *Query8Iter[A, B, C, D, E, F, G, H]) markChanged() {
+	tick := it.query.world.Tick()
+	for _, c := range it.columns {
+		c.MarkChanged(it.query.index, tick)
+	}
+}
@@ -0,0 +1,200 @@
+package ecsbit
+
+import "testing"
+
+func TestQueryN(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+	type Frozen struct{}
+
+	setup := func() (*World, []Entity, ComponentID) {
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		frozenID := w.RegisterComponent(NewComponent[Frozen]())
+		entities := []Entity{
+			w.CreateEntity(posID, velID),
+			w.CreateEntity(posID, velID, frozenID),
+			w.CreateEntity(posID),
+		}
+		for i, e := range entities {
+			Set(w, e, Position{X: float64(i)})
+			if Has[Velocity](w, e) {
+				Set(w, e, Velocity{X: 10})
+			}
+		}
+		return w, entities, frozenID
+	}
+
+	t.Run("iter", func(t *testing.T) {
+		// arrange
+		w, entities, _ := setup()
+
+		// act
+		it := NewQuery2[Position, Velocity](w).Iter()
+		for it.Next() {
+			_, pos, vel := it.Get()
+			pos.X += vel.X
+		}
+
+		// assert
+		if got := Get[Position](w, entities[0]).X; got != 10 {
+			t.Errorf("unexpected result: got %v, want %v", got, 10)
+		}
+		if got := Get[Position](w, entities[1]).X; got != 11 {
+			t.Errorf("unexpected result: got %v, want %v", got, 11)
+		}
+		if got := Get[Position](w, entities[2]).X; got != 2 {
+			t.Errorf("unexpected result: got %v, want %v", got, 2)
+		}
+	})
+
+	t.Run("without", func(t *testing.T) {
+		// arrange
+		w, entities, frozenID := setup()
+
+		// act
+		got := []Entity{}
+		for e, row := range NewQuery2[Position, Velocity](w).Without(frozenID).All() {
+			row.A.X += row.B.X
+			got = append(got, e)
+		}
+
+		// assert
+		if len(got) != 1 || got[0] != entities[0] {
+			t.Errorf("unexpected result: %v", got)
+		}
+	})
+
+	t.Run("range over func with break", func(t *testing.T) {
+		// arrange
+		w, _, _ := setup()
+
+		// act
+		count := 0
+		for _, pos := range NewQuery1[Position](w).All() {
+			_ = pos
+			count++
+			break
+		}
+
+		// assert
+		if count != 1 {
+			t.Errorf("unexpected result: got %v, want %v", count, 1)
+		}
+	})
+}
+
+func TestQueryN_Chunks(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+	type Frozen struct{}
+
+	// arrange
+	w := NewWorld()
+	posID := w.RegisterComponent(NewComponent[Position]())
+	velID := w.RegisterComponent(NewComponent[Velocity]())
+	frozenID := w.RegisterComponent(NewComponent[Frozen]())
+	entities := []Entity{
+		w.CreateEntity(posID, velID),
+		w.CreateEntity(posID, velID),
+		w.CreateEntity(posID, velID, frozenID),
+	}
+	for _, e := range entities {
+		Set(w, e, Velocity{X: 1})
+	}
+
+	// act
+	chunks := 0
+	for chunk := range NewQuery2[Position, Velocity](w).Chunks() {
+		chunks++
+		if len(chunk.A) != len(chunk.Entities) || len(chunk.B) != len(chunk.Entities) {
+			t.Fatalf("unexpected chunk length: %d, %d, %d", len(chunk.Entities), len(chunk.A), len(chunk.B))
+		}
+		for i := range chunk.Entities {
+			chunk.A[i].X += chunk.B[i].X
+		}
+	}
+
+	// assert
+	if chunks != 2 {
+		t.Errorf("unexpected chunk count: got %v, want %v", chunks, 2)
+	}
+	for _, e := range entities {
+		if got := Get[Position](w, e).X; got != 1 {
+			t.Errorf("unexpected result: got %v, want %v", got, 1)
+		}
+	}
+}
+
+func benchmarkQueryWorld(b *testing.B, n int) *World {
+	b.Helper()
+
+	w := NewWorld()
+	posID := w.RegisterComponent(NewComponent[benchPosition]())
+	velID := w.RegisterComponent(NewComponent[benchVelocity]())
+	for i := 0; i < n; i++ {
+		w.CreateEntity(posID, velID)
+	}
+	return w
+}
+
+type benchPosition struct {
+	X, Y float64
+}
+
+type benchVelocity struct {
+	X, Y float64
+}
+
+func BenchmarkQuery2_Iter(b *testing.B) {
+	w := benchmarkQueryWorld(b, 10000)
+	q := NewQuery2[benchPosition, benchVelocity](w)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		it := q.Iter()
+		for it.Next() {
+			_, pos, vel := it.Get()
+			pos.X += vel.X
+			pos.Y += vel.Y
+		}
+	}
+}
+
+func BenchmarkQuery2_All(b *testing.B) {
+	w := benchmarkQueryWorld(b, 10000)
+	q := NewQuery2[benchPosition, benchVelocity](w)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, row := range q.All() {
+			row.A.X += row.B.X
+			row.A.Y += row.B.Y
+		}
+	}
+}
+
+func BenchmarkQuery2_Chunks(b *testing.B) {
+	w := benchmarkQueryWorld(b, 10000)
+	q := NewQuery2[benchPosition, benchVelocity](w)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for chunk := range q.Chunks() {
+			pos, vel := chunk.A, chunk.B
+			for j := range pos {
+				pos[j].X += vel[j].X
+				pos[j].Y += vel[j].Y
+			}
+		}
+	}
+}
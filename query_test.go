@@ -0,0 +1,248 @@
+package ecsbit
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/atEaE/ecsbit/config"
+)
+
+func TestWorld_Query(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+	type Frozen struct{}
+
+	setup := func() (*World, ComponentID, ComponentID, ComponentID) {
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		frozenID := w.RegisterComponent(NewComponent[Frozen]())
+		return w, posID, velID, frozenID
+	}
+
+	t.Run("required", func(t *testing.T) {
+		// arrange
+		w, posID, velID, frozenID := setup()
+		w.CreateEntity(posID)
+		w.CreateEntity(posID, velID)
+		w.CreateEntity(posID, velID, frozenID)
+		w.CreateEntity(velID)
+
+		// act
+		q := w.Query(NewFilter(posID, velID))
+		got := 0
+		for q.Next() {
+			got++
+		}
+
+		// assert
+		if got != 2 {
+			t.Errorf("unexpected result: got %v, want %v", got, 2)
+		}
+		if q.Count() != 2 {
+			t.Errorf("unexpected count: got %v, want %v", q.Count(), 2)
+		}
+	})
+
+	t.Run("without", func(t *testing.T) {
+		// arrange
+		w, posID, velID, frozenID := setup()
+		w.CreateEntity(posID, velID)
+		frozen := w.CreateEntity(posID, velID, frozenID)
+
+		// act
+		q := w.Query(NewFilter(posID).Without(frozenID))
+		got := []Entity{}
+		for q.Next() {
+			got = append(got, q.Entity())
+		}
+
+		// assert
+		if len(got) != 1 || got[0] == frozen {
+			t.Errorf("unexpected result: %v", got)
+		}
+	})
+
+	t.Run("optional and field", func(t *testing.T) {
+		// arrange
+		w, posID, velID, _ := setup()
+		e1 := w.CreateEntity(posID)
+		e2 := w.CreateEntity(posID, velID)
+		Set(w, e1, Position{X: 1})
+		Set(w, e2, Position{X: 2})
+		Set(w, e2, Velocity{X: 10})
+		pos := NewField[Position](w)
+		vel := NewField[Velocity](w)
+
+		// act
+		q := w.Query(NewFilter(pos.ID()).Optional(vel.ID()))
+		for q.Next() {
+			if v := vel.Get(&q); v != nil {
+				pos.Get(&q).X += v.X
+			}
+		}
+
+		// assert
+		if got := Get[Position](w, e1).X; got != 1 {
+			t.Errorf("unexpected result: got %v, want %v", got, 1)
+		}
+		if got := Get[Position](w, e2).X; got != 12 {
+			t.Errorf("unexpected result: got %v, want %v", got, 12)
+		}
+	})
+
+	t.Run("empty filter matches all", func(t *testing.T) {
+		// arrange
+		w, posID, velID, _ := setup()
+		w.CreateEntity()
+		w.CreateEntity(posID)
+		w.CreateEntity(velID)
+
+		// act
+		q := w.Query(NewFilter())
+
+		// assert
+		if q.Count() != 3 {
+			t.Errorf("unexpected count: got %v, want %v", q.Count(), 3)
+		}
+	})
+
+	t.Run("range over func", func(t *testing.T) {
+		// arrange
+		w, posID, velID, _ := setup()
+		a := w.CreateEntity(posID)
+		w.CreateEntity(velID)
+		b := w.CreateEntity(posID, velID)
+
+		// act
+		q := w.Query(NewFilter(posID))
+		got := []Entity{}
+		for e := range q.All() {
+			got = append(got, e)
+		}
+
+		// assert
+		if want := []Entity{a, b}; !slices.Equal(got, want) {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestQuery_IterationGuard(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+
+	setup := func(opts ...config.WorldConfigOption) (*World, ComponentID) {
+		w := NewWorld(opts...)
+		posID := w.RegisterComponent(NewComponent[Position]())
+		w.CreateEntity(posID)
+		w.CreateEntity(posID)
+		return w, posID
+	}
+
+	t.Run("structural change panics while iterating", func(t *testing.T) {
+		// arrange
+		w, posID := setup()
+		q := w.Query(NewFilter(posID))
+		q.Next()
+
+		// act & assert
+		defer func() {
+			err := recover()
+			if err == nil {
+				t.Errorf("expected panic, but not occurred")
+			}
+			if !errors.Is(err.(error), ErrWorldLocked) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+		w.CreateEntity(posID)
+	})
+
+	t.Run("unlocked after iteration and close", func(t *testing.T) {
+		// arrange
+		w, posID := setup()
+
+		// act
+		q := w.Query(NewFilter(posID))
+		for q.Next() {
+		}
+		lockedAfterIteration := w.IsLocked()
+
+		q = w.Query(NewFilter(posID))
+		q.Next()
+		q.Close()
+		lockedAfterClose := w.IsLocked()
+
+		for range NewQuery1[Position](w).All() {
+			break
+		}
+		lockedAfterBreak := w.IsLocked()
+
+		// assert
+		if lockedAfterIteration || lockedAfterClose || lockedAfterBreak {
+			t.Errorf("unexpected locked: %v, %v, %v", lockedAfterIteration, lockedAfterClose, lockedAfterBreak)
+		}
+		w.CreateEntity(posID)
+	})
+
+	t.Run("unlocked after break and panic in range over func", func(t *testing.T) {
+		// arrange
+		w, posID := setup()
+		q := w.Query(NewFilter(posID))
+		recovered := func(f func()) (r any) {
+			defer func() { r = recover() }()
+			f()
+			return nil
+		}
+
+		// act
+		for range q.All() {
+			break
+		}
+		lockedAfterBreak := w.IsLocked()
+
+		for range NewQuery1[Position](w).Chunks() {
+			break
+		}
+		lockedAfterChunks := w.IsLocked()
+
+		r := recovered(func() {
+			for range NewQuery1[Position](w).All() {
+				panic("boom")
+			}
+		})
+		lockedAfterPanic := w.IsLocked()
+
+		// assert
+		if r != "boom" {
+			t.Errorf("unexpected result: got %v, want %v", r, "boom")
+		}
+		if lockedAfterBreak || lockedAfterChunks || lockedAfterPanic {
+			t.Errorf("unexpected locked: %v, %v, %v", lockedAfterBreak, lockedAfterChunks, lockedAfterPanic)
+		}
+		w.CreateEntity(posID)
+	})
+
+	t.Run("guard disabled", func(t *testing.T) {
+		// arrange
+		w, posID := setup(config.WithIterationGuard(false))
+
+		// act
+		q := w.Query(NewFilter(posID))
+		q.Next()
+		w.CreateEntity(posID)
+		q.Close()
+
+		// assert
+		if w.IsLocked() {
+			t.Errorf("expected not locked")
+		}
+	})
+}
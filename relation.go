@@ -0,0 +1,164 @@
+package ecsbit
+
+import (
+	"reflect"
+
+	"github.com/atEaE/ecsbit/internal/bits"
+)
+
+const (
+	// noTarget : RelationのTargetが未設定であることを表すEntity（EntityID = 0はsentinelのため、実在するEntityと重複しない）
+	noTarget Entity = 0
+)
+
+// relationType : Relationの型情報
+var relationType = reflect.TypeFor[Relation]()
+
+// Relation : Componentを、別のEntityを対象（Target）とするRelationとして扱うためのマーカー
+// 先頭のフィールドに埋め込んだ型をComponentとして登録すると、Relationとして扱われます.
+//
+//	type ChildOf struct {
+//		ecsbit.Relation
+//	}
+//
+// RelationのTargetはEntity毎に保持されるため、Targetが異なってもLayoutが同じEntityは同じArchetypeにまとめられます.
+// 1つのEntityは、異なる種類のRelationをそれぞれ1つずつ持てますが、Relationの種類毎にTargetは1つだけです.
+// 同じ種類のRelationでTargetを設定し直すと、以前のTargetは置き換えられます
+type Relation struct{}
+
+// relationKey : RelationとTargetの組
+type relationKey struct {
+	relation ComponentID
+	target   Entity
+}
+
+// relationSource : Relationを持つEntityとRelationの組
+type relationSource struct {
+	entity   Entity
+	relation ComponentID
+}
+
+// isRelationType : Relationを先頭に埋め込んだ型かどうかを返します
+func isRelationType(typ reflect.Type) bool {
+	if typ == nil || typ.Kind() != reflect.Struct || typ.NumField() == 0 {
+		return false
+	}
+	field := typ.Field(0)
+	return field.Anonymous && field.Type == relationType
+}
+
+// SetRelation : EntityのRelationのTargetを設定します
+// EntityはRelationのComponentを持っている必要があり、持っていない場合はErrMissingComponentでpanicします.
+// Relationではない場合はErrNotRelation、TargetがすでにDeadの場合はErrDeadEntityOperationでpanicします.
+// Targetにゼロ値のEntityを指定すると、Targetが未設定の状態になります.
+// Targetの変更はComponentの変更として記録され、WithRelationで絞り込むObserverにも通知されます
+func (w *World) SetRelation(e Entity, relation ComponentID, target Entity) {
+	w.checkLocked()
+	if !w.componentStorage.IsRelation(relation) {
+		panic(ErrNotRelation)
+	}
+	if target != noTarget && !w.Alive(target) {
+		panic(ErrDeadEntityOperation)
+	}
+	index, err := w.entityIndex(e)
+	if err != nil {
+		panic(err)
+	}
+	if !index.archetype.HasComponent(relation) {
+		panic(ErrMissingComponent)
+	}
+	w.setTarget(e, index, relation, target)
+}
+
+// GetRelation : EntityのRelationのTargetを取得します. 未設定の場合はゼロ値のEntityを返します
+// Relationではない場合はErrNotRelation、EntityがRelationのComponentを持っていない場合はErrMissingComponentでpanicします
+func (w *World) GetRelation(e Entity, relation ComponentID) Entity {
+	if !w.componentStorage.IsRelation(relation) {
+		panic(ErrNotRelation)
+	}
+	index, err := w.entityIndex(e)
+	if err != nil {
+		panic(err)
+	}
+	c := index.archetype.Column(relation)
+	if c == nil {
+		panic(ErrMissingComponent)
+	}
+	return c.targets[index.index]
+}
+
+// setTarget : EntityのRelationのTargetを変更し、変更によってFilterへの一致が変わったObserverに通知します
+// Targetの変更はComponentの変更として記録します
+func (w *World) setTarget(e Entity, index *EntityIndex, relation ComponentID, target Entity) {
+	c := index.archetype.Column(relation)
+	old := c.targets[index.index]
+	if old == target {
+		return
+	}
+	before := w.observing(e)
+	if old != noTarget {
+		w.unlinkTarget(e, relation, old)
+	}
+	c.targets[index.index] = target
+	c.MarkChanged(index.index, w.Tick())
+	if target != noTarget {
+		w.linkTarget(e, relation, target)
+	}
+	w.notifyRetarget(e, before)
+}
+
+// linkTarget : EntityをTargetの逆引きに追加します
+func (w *World) linkTarget(e Entity, relation ComponentID, target Entity) {
+	key := relationKey{relation: relation, target: target}
+	w.relationPositions[relationSource{entity: e, relation: relation}] = len(w.relationSources[key])
+	w.relationSources[key] = append(w.relationSources[key], e)
+}
+
+// unlinkTarget : EntityをTargetの逆引きから取り除きます
+// 末尾のEntityと入れ替えることで、削除処理を高速化する
+func (w *World) unlinkTarget(e Entity, relation ComponentID, target Entity) {
+	key := relationKey{relation: relation, target: target}
+	source := relationSource{entity: e, relation: relation}
+	sources := w.relationSources[key]
+	i, last := w.relationPositions[source], len(sources)-1
+	if i != last {
+		sources[i] = sources[last]
+		w.relationPositions[relationSource{entity: sources[i], relation: relation}] = i
+	}
+	delete(w.relationPositions, source)
+	if last == 0 {
+		delete(w.relationSources, key)
+		return
+	}
+	w.relationSources[key] = sources[:last]
+}
+
+// unlinkRelations : layoutに含まれるRelationのTargetを未設定に戻し、逆引きから取り除きます
+// Entityの削除やRelationの削除の前に呼び出します（Observerへの通知は行いません）
+func (w *World) unlinkRelations(e Entity, index *EntityIndex, layout *bits.Mask256) {
+	if !layout.Intersects(&w.componentStorage.Relations) {
+		return
+	}
+	for _, relation := range convertToComponentIDs(&w.componentStorage.Relations) {
+		if !layout.Get(uint32(relation)) {
+			continue
+		}
+		c := index.archetype.Column(relation)
+		if target := c.targets[index.index]; target != noTarget {
+			w.unlinkTarget(e, relation, target)
+			c.targets[index.index] = noTarget
+		}
+	}
+}
+
+// releaseTarget : 削除されたEntityをTargetとしていたEntityのTargetを未設定に戻します
+func (w *World) releaseTarget(target Entity) {
+	for _, relation := range convertToComponentIDs(&w.componentStorage.Relations) {
+		key := relationKey{relation: relation, target: target}
+		// Targetを戻す度に逆引きから取り除かれるので、末尾から順に戻す
+		for sources := w.relationSources[key]; len(sources) > 0; sources = w.relationSources[key] {
+			e := sources[len(sources)-1]
+			w.setTarget(e, &w.entityIndices[e.ID()], relation, noTarget)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package ecsbit
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWorld_Relation(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type ChildOf struct {
+		Relation
+	}
+	type Likes struct {
+		Relation
+	}
+
+	setup := func() (*World, ComponentID, ComponentID, ComponentID) {
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		childOfID := w.RegisterComponent(NewComponent[ChildOf]())
+		likesID := w.RegisterComponent(NewComponent[Likes]())
+		return w, posID, childOfID, likesID
+	}
+
+	t.Run("set and get", func(t *testing.T) {
+		// arrange
+		w, posID, childOfID, _ := setup()
+		parent := w.CreateEntity(posID)
+		child := w.CreateEntity(posID, childOfID)
+		Set(w, child, Position{X: 1, Y: 2})
+
+		since := w.Tick()
+		w.AdvanceTick()
+
+		// act
+		before := w.GetRelation(child, childOfID)
+		w.SetRelation(child, childOfID, parent)
+
+		// assert
+		if before != noTarget {
+			t.Errorf("unexpected result: got %v, want %v", before, noTarget)
+		}
+		// Targetの変更はComponentの変更として記録される
+		q := w.Query(NewFilter().Changed(childOfID).Since(since))
+		if got := q.Count(); got != 1 {
+			t.Errorf("unexpected result: got %v, want %v", got, 1)
+		}
+		if got := w.GetRelation(child, childOfID); got != parent {
+			t.Errorf("unexpected result: got %v, want %v", got, parent)
+		}
+		// Targetを変更してもComponentのデータは引き継がれる
+		if got := Get[Position](w, child); got.X != 1 || got.Y != 2 {
+			t.Errorf("unexpected result: got %v, want %v", got, Position{X: 1, Y: 2})
+		}
+	})
+
+	t.Run("multiple relations", func(t *testing.T) {
+		// arrange
+		w, posID, childOfID, likesID := setup()
+		parent := w.CreateEntity(posID)
+		liked := w.CreateEntity(posID)
+		e := w.CreateEntity(childOfID, likesID)
+
+		// act
+		w.SetRelation(e, childOfID, parent)
+		w.SetRelation(e, likesID, liked)
+
+		// assert
+		if got := w.GetRelation(e, childOfID); got != parent {
+			t.Errorf("unexpected result: got %v, want %v", got, parent)
+		}
+		if got := w.GetRelation(e, likesID); got != liked {
+			t.Errorf("unexpected result: got %v, want %v", got, liked)
+		}
+		q := w.Query(NewFilter().WithRelation(childOfID, parent).WithRelation(likesID, liked))
+		if got := q.Count(); got != 1 {
+			t.Errorf("unexpected result: got %v, want %v", got, 1)
+		}
+		q = w.Query(NewFilter().WithRelation(childOfID, parent).WithRelation(likesID, parent))
+		if got := q.Count(); got != 0 {
+			t.Errorf("unexpected result: got %v, want %v", got, 0)
+		}
+	})
+
+	t.Run("query by target", func(t *testing.T) {
+		// arrange
+		w, posID, childOfID, _ := setup()
+		parentA := w.CreateEntity(posID)
+		parentB := w.CreateEntity(posID)
+		for i := 0; i < 3; i++ {
+			e := w.CreateEntity(posID, childOfID)
+			w.SetRelation(e, childOfID, parentA)
+		}
+		for i := 0; i < 2; i++ {
+			e := w.CreateEntity(posID, childOfID)
+			w.SetRelation(e, childOfID, parentB)
+		}
+		cq := w.RegisterQuery(NewFilter().WithRelation(childOfID, parentB))
+
+		// act
+		all := w.Query(NewFilter(childOfID))
+		byA := w.Query(NewFilter().WithRelation(childOfID, parentA))
+		byB := cq.Query()
+		entities := []Entity{}
+		for e := range byB.All() {
+			entities = append(entities, e)
+		}
+
+		// assert
+		// Targetが異なってもLayoutが同じEntityは同じArchetypeにまとめられる
+		if got := w.Stats().Archetypes.Count; got != 3 {
+			t.Errorf("unexpected result: got %v, want %v", got, 3)
+		}
+		for _, e := range entities {
+			if got := w.GetRelation(e, childOfID); got != parentB {
+				t.Errorf("unexpected result: got %v, want %v", got, parentB)
+			}
+		}
+		if got := all.Count(); got != 5 {
+			t.Errorf("unexpected result: got %v, want %v", got, 5)
+		}
+		if got := byA.Count(); got != 3 {
+			t.Errorf("unexpected result: got %v, want %v", got, 3)
+		}
+		if got := byB.Count(); got != 2 {
+			t.Errorf("unexpected result: got %v, want %v", got, 2)
+		}
+	})
+
+	t.Run("query by target with other conditions", func(t *testing.T) {
+		// arrange
+		w, posID, childOfID, likesID := setup()
+		parent := w.CreateEntity(posID)
+		other := w.CreateEntity(posID)
+		children := w.CreateEntities(4, posID, childOfID, likesID)
+		for _, e := range children {
+			w.SetRelation(e, childOfID, parent)
+		}
+		w.SetRelation(children[0], likesID, other)
+		w.SetRelation(children[1], likesID, other)
+		w.RemoveComponent(children[1], posID)
+		unset := w.CreateEntity(childOfID)
+
+		since := w.Tick()
+		w.AdvanceTick()
+		Set(w, children[2], Position{X: 1})
+
+		// act
+		collect := func(f Filter) []Entity {
+			q := w.Query(f)
+			entities := []Entity{}
+			for e := range q.All() {
+				entities = append(entities, e)
+			}
+			return entities
+		}
+		liked := collect(NewFilter().WithRelation(childOfID, parent).WithRelation(likesID, other))
+		withPos := collect(NewFilter(posID).WithRelation(childOfID, parent))
+		changed := collect(NewFilter().WithRelation(childOfID, parent).Changed(posID).Since(since))
+		unsetTarget := collect(NewFilter().WithRelation(childOfID, noTarget))
+
+		// assert
+		// 逆引きから走査しても、Layout、他のRelation、Changedの条件で絞り込まれる
+		for name, tc := range map[string]struct {
+			got  []Entity
+			want []Entity
+		}{
+			"liked":    {got: liked, want: []Entity{children[0], children[1]}},
+			"with pos": {got: withPos, want: []Entity{children[0], children[2], children[3]}},
+			"changed":  {got: changed, want: []Entity{children[2]}},
+			"unset":    {got: unsetTarget, want: []Entity{unset}},
+		} {
+			if !slices.Equal(slices.Sorted(slices.Values(tc.got)), slices.Sorted(slices.Values(tc.want))) {
+				t.Errorf("%s: unexpected result: got %v, want %v", name, tc.got, tc.want)
+			}
+		}
+	})
+
+	t.Run("remove target", func(t *testing.T) {
+		// arrange
+		w, posID, childOfID, _ := setup()
+		parent := w.CreateEntity(posID)
+		children := []Entity{w.CreateEntity(childOfID), w.CreateEntity(childOfID)}
+		for _, c := range children {
+			w.SetRelation(c, childOfID, parent)
+		}
+		archetypes := w.Stats().Archetypes.Count
+
+		// act
+		w.RemoveEntity(parent)
+		other := w.CreateEntity(posID)
+		w.SetRelation(children[0], childOfID, other)
+
+		// assert
+		if got := w.GetRelation(children[1], childOfID); got != noTarget {
+			t.Errorf("unexpected result: got %v, want %v", got, noTarget)
+		}
+		if got := w.GetRelation(children[0], childOfID); got != other {
+			t.Errorf("unexpected result: got %v, want %v", got, other)
+		}
+		// Targetの変更や削除でArchetypeは生成されない
+		if got := w.Stats().Archetypes.Count; got != archetypes {
+			t.Errorf("unexpected result: got %v, want %v", got, archetypes)
+		}
+	})
+
+	t.Run("observe target", func(t *testing.T) {
+		// arrange
+		w, posID, childOfID, _ := setup()
+		parentA := w.CreateEntity(posID)
+		parentB := w.CreateEntity(posID)
+		e := w.CreateEntity(childOfID)
+		log := []string{}
+		w.Observe(
+			NewFilter().WithRelation(childOfID, parentA),
+			func(w *World, e Entity) { log = append(log, "enter") },
+			func(w *World, e Entity) { log = append(log, "exit") },
+		)
+
+		// act
+		w.SetRelation(e, childOfID, parentA) // enter
+		w.SetRelation(e, childOfID, parentB) // exit
+		w.SetRelation(e, childOfID, parentA) // enter
+		w.RemoveEntity(parentA)              // exit
+
+		// assert
+		want := "enter,exit,enter,exit"
+		if got := strings.Join(log, ","); got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("release sources", func(t *testing.T) {
+		// arrange
+		w, posID, childOfID, likesID := setup()
+		target := w.CreateEntity(posID)
+		entities := w.CreateEntities(4, childOfID, likesID)
+		for _, e := range entities {
+			w.SetRelation(e, childOfID, target)
+			w.SetRelation(e, likesID, target)
+		}
+
+		// act
+		w.RemoveComponent(entities[0], likesID)
+		w.RemoveEntity(entities[1])
+		removed := w.RemoveMatching(NewFilter().WithRelation(childOfID, target))
+
+		// assert
+		if removed != 3 {
+			t.Errorf("unexpected result: got %v, want %v", removed, 3)
+		}
+		if len(w.relationSources) != 0 || len(w.relationPositions) != 0 {
+			t.Errorf("unexpected sources: %v, %v", w.relationSources, w.relationPositions)
+		}
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		tests := []struct {
+			name string
+			act  func(w *World, posID, childOfID, likesID ComponentID)
+			want error
+		}{
+			{
+				name: "not relation",
+				act: func(w *World, posID, childOfID, likesID ComponentID) {
+					e := w.CreateEntity(posID)
+					w.SetRelation(e, posID, w.CreateEntity())
+				},
+				want: ErrNotRelation,
+			},
+			{
+				name: "get not relation",
+				act: func(w *World, posID, childOfID, likesID ComponentID) {
+					e := w.CreateEntity(posID)
+					w.GetRelation(e, posID)
+				},
+				want: ErrNotRelation,
+			},
+			{
+				name: "missing relation",
+				act: func(w *World, posID, childOfID, likesID ComponentID) {
+					e := w.CreateEntity(posID)
+					w.SetRelation(e, childOfID, w.CreateEntity())
+				},
+				want: ErrMissingComponent,
+			},
+			{
+				name: "dead target",
+				act: func(w *World, posID, childOfID, likesID ComponentID) {
+					e := w.CreateEntity(childOfID)
+					target := w.CreateEntity()
+					w.RemoveEntity(target)
+					w.SetRelation(e, childOfID, target)
+				},
+				want: ErrDeadEntityOperation,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// arrange
+				w, posID, childOfID, likesID := setup()
+				defer func() {
+					// assert
+					r := recover()
+					if err, ok := r.(error); !ok || !errors.Is(err, tt.want) {
+						t.Errorf("unexpected result: got %v, want %v", r, tt.want)
+					}
+				}()
+
+				// act
+				tt.act(w, posID, childOfID, likesID)
+			})
+		}
+	})
+}
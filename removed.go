@@ -0,0 +1,123 @@
+package ecsbit
+
+import (
+	"iter"
+	"reflect"
+
+	"github.com/atEaE/ecsbit/internal/bits"
+)
+
+// Removed : 削除されたComponentの記録
+type Removed[T any] struct {
+	Entity Entity // Componentが削除されたEntity（RemoveEntityで削除された場合は既に死んでいます）
+	Value  T      // 削除される直前の値（TrackRemovedでkeepValuesを指定していない場合はゼロ値）
+	Tick   uint32 // 削除された時点のTick
+}
+
+// removedLog : 1種類のComponentの削除の記録
+// 記録はClearRemovedの度に入れ替わる2つのバッファで保持し、直前のClearRemoved以降の記録と、その1つ前の記録を参照できる
+type removedLog struct {
+	keepValues bool             // 削除される直前の値を記録するかどうか
+	buffers    [2]removedBuffer // [0] : 直前のClearRemovedより前の記録、[1] : 直前のClearRemoved以降の記録
+}
+
+// removedBuffer : 削除の記録を保持するバッファ
+type removedBuffer struct {
+	entities []Entity
+	ticks    []uint32
+	values   reflect.Value // 削除される直前の値のslice（keepValuesがtrueの場合のみ有効）
+}
+
+// TrackRemoved : Componentの削除を記録するようにします
+// RemoveComponent、RemoveEntityによる削除が記録され、RemovedComponentsで参照できます.
+// keepValuesにtrueを指定した場合は、削除される直前の値も記録します
+func (w *World) TrackRemoved(id ComponentID, keepValues bool) {
+	log := w.removedLogs[id]
+	if log == nil {
+		log = &removedLog{}
+		w.removedLogs[id] = log
+		w.removedTracked.Set(uint32(id), true)
+	}
+	if keepValues && !log.keepValues {
+		// 既に記録済みのものは値を持たないので、ゼロ値で埋めておく
+		typ := reflect.SliceOf(w.componentStorage.Types[id].Type())
+		for i := range log.buffers {
+			n := len(log.buffers[i].entities)
+			log.buffers[i].values = reflect.MakeSlice(typ, n, n)
+		}
+		log.keepValues = true
+	}
+}
+
+// RemovedComponents : sinceで指定したTickより後に削除されたComponentの記録を、削除された順に走査します
+// 記録はClearRemovedを2回呼び出すと破棄されます. Schedulerを利用している場合は、Systemの実行時点で
+// 同じフレームと1つ前のフレームで削除された記録を参照できます.
+// 型が登録されていない場合はErrUnregisteredComponent、TrackRemovedで記録していない場合はErrRemovedNotTrackedでpanicします
+func RemovedComponents[T any](w *World, since uint32) iter.Seq[Removed[T]] {
+	id, err := componentIDOf[T](w)
+	if err != nil {
+		panic(err)
+	}
+	log := w.removedLogs[id]
+	if log == nil {
+		panic(ErrRemovedNotTracked)
+	}
+
+	return func(yield func(Removed[T]) bool) {
+		for i := range log.buffers {
+			b := &log.buffers[i]
+			var values []T
+			if log.keepValues {
+				values = b.values.Interface().([]T)
+			}
+			for j, e := range b.entities {
+				if b.ticks[j] <= since {
+					continue
+				}
+				r := Removed[T]{Entity: e, Tick: b.ticks[j]}
+				if values != nil {
+					r.Value = values[j]
+				}
+				if !yield(r) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ClearRemoved : 削除の記録を入れ替え、1つ前のClearRemovedより前の記録を破棄します
+// Schedulerを利用している場合は、WithRemovedClearStageで指定したStageの終了時に自動で呼び出されます
+func (w *World) ClearRemoved() {
+	for _, id := range convertToComponentIDs(&w.removedTracked) {
+		log := w.removedLogs[id]
+		log.buffers[0], log.buffers[1] = log.buffers[1], log.buffers[0]
+		b := &log.buffers[1]
+		b.entities, b.ticks = b.entities[:0], b.ticks[:0]
+		if log.keepValues {
+			// GCが参照を回収できるように、ゼロ値に戻してから長さを0にする
+			b.values.Clear()
+			b.values = b.values.Slice(0, 0)
+		}
+	}
+}
+
+// recordRemoved : layoutに含まれるComponentのうち、記録対象のものの削除を記録します
+func (w *World) recordRemoved(e Entity, index *EntityIndex, layout *bits.Mask256) {
+	if !layout.Intersects(&w.removedTracked) {
+		return
+	}
+	tick := w.Tick()
+	for _, id := range convertToComponentIDs(layout) {
+		log := w.removedLogs[id]
+		if log == nil {
+			continue
+		}
+		b := &log.buffers[1]
+		b.entities = append(b.entities, e)
+		b.ticks = append(b.ticks, tick)
+		if log.keepValues {
+			b.values = reflect.Append(b.values, index.archetype.Column(id).data.Index(int(index.index)))
+		}
+	}
+}
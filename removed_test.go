@@ -0,0 +1,130 @@
+package ecsbit
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWorld_RemovedComponents(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+
+	collect := func(seq func(func(Removed[Position]) bool)) []Removed[Position] {
+		removed := []Removed[Position]{}
+		for r := range seq {
+			removed = append(removed, r)
+		}
+		return removed
+	}
+
+	t.Run("remove component and entity", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		w.TrackRemoved(posID, true)
+		a, b := w.CreateEntity(posID, velID), w.CreateEntity(posID)
+		Set(w, a, Position{X: 1})
+		Set(w, b, Position{X: 2})
+
+		// act
+		w.RemoveComponent(a, posID)
+		w.RemoveComponent(a, velID)
+		w.RemoveEntity(b)
+
+		// assert
+		got := collect(RemovedComponents[Position](w, 0))
+		want := []Removed[Position]{
+			{Entity: a, Value: Position{X: 1}, Tick: w.Tick()},
+			{Entity: b, Value: Position{X: 2}, Tick: w.Tick()},
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("since and clear", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		w.TrackRemoved(posID, false)
+		first, second := w.CreateEntity(posID), w.CreateEntity(posID)
+		w.RemoveEntity(first)
+		since := w.AdvanceTick()
+		w.AdvanceTick()
+		w.ClearRemoved()
+		w.RemoveEntity(second)
+
+		// act
+		all := collect(RemovedComponents[Position](w, 0))
+		after := collect(RemovedComponents[Position](w, since))
+		w.ClearRemoved()
+		w.ClearRemoved()
+		cleared := collect(RemovedComponents[Position](w, 0))
+
+		// assert
+		if len(all) != 2 || all[0].Entity != first || all[1].Entity != second {
+			t.Errorf("unexpected result: got %v", all)
+		}
+		if len(after) != 1 || after[0].Entity != second {
+			t.Errorf("unexpected result: got %v", after)
+		}
+		if len(cleared) != 0 {
+			t.Errorf("unexpected result: got %v, want empty", cleared)
+		}
+	})
+
+	t.Run("scheduler", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		w.TrackRemoved(posID, false)
+		var ticks SystemTicks
+		counts := []int{}
+		s := NewScheduler()
+		if err := s.AddSystem(StageUpdate, "despawn", SystemFunc(func(w *World, dt time.Duration) {
+			since := ticks.Update(w)
+			counts = append(counts, len(collect(RemovedComponents[Position](w, since))))
+			if len(counts) == 1 {
+				w.Commands().RemoveEntity(w.CreateEntity(posID))
+			}
+		})); err != nil {
+			t.Fatal(err)
+		}
+
+		// act
+		for range 3 {
+			if err := s.Update(w, time.Millisecond); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// assert
+		// CommandBufferで削除されたものは、次のフレームで1度だけ参照できる
+		if want := []int{0, 1, 0}; !slices.Equal(counts, want) {
+			t.Errorf("unexpected result: got %v, want %v", counts, want)
+		}
+	})
+
+	t.Run("not tracked", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		w.RegisterComponent(NewComponent[Position]())
+		defer func() {
+			// assert
+			r := recover()
+			if err, ok := r.(error); !ok || !errors.Is(err, ErrRemovedNotTracked) {
+				t.Errorf("unexpected result: got %v, want %v", r, ErrRemovedNotTracked)
+			}
+		}()
+
+		// act
+		RemovedComponents[Position](w, 0)
+	})
+}
@@ -0,0 +1,84 @@
+package ecsbit
+
+import (
+	"reflect"
+
+	"github.com/atEaE/ecsbit/internal/bits"
+)
+
+// ResourceID : World単位でResourceを一意に表すID
+type ResourceID uint32
+
+const (
+	// registeredResourceMaxSize : 登録可能なResourceの最大数
+	// Accessで宣言するビットマスクの最大サイズに合わせて設定している
+	registeredResourceMaxSize = bits.Mask256Max
+)
+
+// newResourceStorage : resourceStorageを生成する
+func newResourceStorage() resourceStorage {
+	return resourceStorage{
+		ids:    make(map[reflect.Type]ResourceID),
+		values: make([]any, 0),
+	}
+}
+
+// resourceStorage : Entityに紐付かないResourceを型毎に保管するストレージ
+type resourceStorage struct {
+	ids    map[reflect.Type]ResourceID // 型からResourceIDを引くためのMap
+	values []any                       // ResourceIDをIndexとしたResourceのポインタ（未設定の場合はnil）
+}
+
+// id : 型からResourceIDを取得する. 登録されていない場合は登録後のIDを返す
+func (s *resourceStorage) id(typ reflect.Type) ResourceID {
+	if id, ok := s.ids[typ]; ok {
+		return id
+	}
+	if len(s.values) >= int(registeredResourceMaxSize) {
+		panic("resourceStorage is full")
+	}
+	id := ResourceID(len(s.values))
+	s.ids[typ] = id
+	s.values = append(s.values, nil)
+	return id
+}
+
+// ResourceIDOf : Resourceの型からResourceIDを取得します
+// Accessで参照、更新するResourceを宣言する際に利用します. Resourceが未設定の場合でもIDは採番されます
+func ResourceIDOf[T any](w *World) ResourceID {
+	return w.resources.id(reflect.TypeFor[T]())
+}
+
+// SetResource : Resourceを設定します
+// 既に設定済みの場合は値を上書きするため、GetResourceで取得済みのポインタは引き続き有効です.
+// 新しい型のResourceの設定はWorldの構造を変更するため、Systemの並列実行中には行わないでください
+func SetResource[T any](w *World, v T) {
+	id := ResourceIDOf[T](w)
+	if p, ok := w.resources.values[id].(*T); ok {
+		*p = v
+		return
+	}
+	w.resources.values[id] = &v
+}
+
+// GetResource : Resourceのポインタを取得します. 設定されていない場合はnilを返します
+func GetResource[T any](w *World) *T {
+	id, ok := w.resources.ids[reflect.TypeFor[T]()]
+	if !ok {
+		return nil
+	}
+	p, _ := w.resources.values[id].(*T)
+	return p
+}
+
+// HasResource : Resourceが設定されているかどうかを返します
+func HasResource[T any](w *World) bool {
+	return GetResource[T](w) != nil
+}
+
+// RemoveResource : Resourceを削除します. 設定されていない場合は何もしません
+func RemoveResource[T any](w *World) {
+	if id, ok := w.resources.ids[reflect.TypeFor[T]()]; ok {
+		w.resources.values[id] = nil
+	}
+}
@@ -0,0 +1,64 @@
+package ecsbit
+
+import "testing"
+
+func TestResource(t *testing.T) {
+	type Time struct {
+		Delta float64
+	}
+	type Gravity struct {
+		Y float64
+	}
+
+	t.Run("set and get", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+
+		// act
+		SetResource(w, Time{Delta: 0.5})
+
+		// assert
+		got := GetResource[Time](w)
+		if got == nil || got.Delta != 0.5 {
+			t.Fatalf("unexpected result: got %v, want %v", got, Time{Delta: 0.5})
+		}
+		if GetResource[Gravity](w) != nil {
+			t.Errorf("unset resource should be nil")
+		}
+	})
+
+	t.Run("overwrite keeps pointer", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		SetResource(w, Time{Delta: 0.5})
+		p := GetResource[Time](w)
+
+		// act
+		SetResource(w, Time{Delta: 1})
+
+		// assert
+		if p.Delta != 1 {
+			t.Errorf("unexpected result: got %v, want %v", p.Delta, 1)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		SetResource(w, Time{Delta: 0.5})
+		id := ResourceIDOf[Time](w)
+
+		// act
+		RemoveResource[Time](w)
+		RemoveResource[Gravity](w)
+
+		// assert
+		if HasResource[Time](w) {
+			t.Errorf("resource should be removed")
+		}
+		// 削除してもIDは変わらない
+		if got := ResourceIDOf[Time](w); got != id {
+			t.Errorf("unexpected result: got %v, want %v", got, id)
+		}
+	})
+}
@@ -0,0 +1,401 @@
+package ecsbit
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stage : Systemを実行する段階
+// Startupは最初のUpdate時に1度だけ実行され、以降はPreUpdate, Update, PostUpdateの順に実行されます
+type Stage int
+
+const (
+	StageStartup    Stage = iota // 最初のUpdate時に1度だけ実行する段階
+	StagePreUpdate               // Updateの前に実行する段階
+	StageUpdate                  // メインの処理を実行する段階
+	StagePostUpdate              // Updateの後に実行する段階
+
+	stageCount = iota
+)
+
+// String : Stageを文字列に変換します
+func (s Stage) String() string {
+	switch s {
+	case StageStartup:
+		return "Startup"
+	case StagePreUpdate:
+		return "PreUpdate"
+	case StageUpdate:
+		return "Update"
+	case StagePostUpdate:
+		return "PostUpdate"
+	default:
+		return fmt.Sprintf("Stage(%d)", int(s))
+	}
+}
+
+// SystemOption : Systemの登録時に指定するオプション
+type SystemOption func(*systemEntry)
+
+// Before : 指定したSystemより前に実行します（同じStageに登録されたSystemのみが対象です）
+func Before(names ...string) SystemOption {
+	return func(e *systemEntry) {
+		e.before = append(e.before, names...)
+	}
+}
+
+// After : 指定したSystemより後に実行します（同じStageに登録されたSystemのみが対象です）
+func After(names ...string) SystemOption {
+	return func(e *systemEntry) {
+		e.after = append(e.after, names...)
+	}
+}
+
+// WithAccess : Systemが参照、更新するComponentを宣言します
+// SystemがAccessDeclarerを実装している場合は、こちらの指定が優先されます
+func WithAccess(access Access) SystemOption {
+	return func(e *systemEntry) {
+		e.access = &access
+	}
+}
+
+// SchedulerOption : Schedulerの生成時に指定するオプション
+type SchedulerOption func(*Scheduler)
+
+// WithParallel : 競合しないSystemを指定した数のgoroutineで並列に実行します
+// 1以下を指定した場合は、登録順に直列で実行します
+func WithParallel(workers int) SchedulerOption {
+	return func(s *Scheduler) {
+		s.workers = workers
+	}
+}
+
+// WithRemovedClearStage : World.ClearRemovedを呼び出すStageを設定します（デフォルトはStagePostUpdate）
+// 指定したStageの終了時に呼び出されるため、削除の記録は次のフレームの同じStageの終了時まで参照できます
+func WithRemovedClearStage(stage Stage) SchedulerOption {
+	return func(s *Scheduler) {
+		s.removedClearStage = stage
+	}
+}
+
+// NewScheduler : Schedulerを生成します
+func NewScheduler(opts ...SchedulerOption) *Scheduler {
+	s := &Scheduler{
+		systems:           make(map[string]*systemEntry),
+		workers:           1,
+		removedClearStage: StagePostUpdate,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Scheduler : 登録されたSystemをStage毎に順序付けして実行する構造体
+type Scheduler struct {
+	systems map[string]*systemEntry    // 名前からSystemを引くためのMap
+	stages  [stageCount][]*systemEntry // Stage毎の登録順のSystem
+	orders  [stageCount][]*systemEntry // Stage毎の実行順のSystem（Buildで確定する）
+	graphs  [stageCount]systemGraph    // Stage毎の並列実行用の依存グラフ（Buildで確定する）
+	workers int                        // 並列実行に利用するgoroutineの数
+	built   bool                       // 実行順が確定しているかどうか
+	started bool                       // Startupを実行済みかどうか
+
+	removedClearStage Stage // World.ClearRemovedを呼び出すStage
+}
+
+// systemEntry : Schedulerに登録されたSystem
+type systemEntry struct {
+	name   string
+	stage  Stage
+	system System
+	before []string // このSystemより後に実行するSystem
+	after  []string // このSystemより前に実行するSystem
+	access *Access  // 参照、更新するComponent（nilの場合は全てのSystemと競合する）
+	deps   []*systemEntry
+}
+
+// conflicts : 並列に実行すると競合するかどうかを返します
+func (e *systemEntry) conflicts(other *systemEntry) bool {
+	if e.access == nil || other.access == nil {
+		return true
+	}
+	return e.access.Conflicts(other.access)
+}
+
+// AddSystem : Systemを登録します
+// 名前はScheduler内で一意である必要があり、重複した場合はErrDuplicateSystemを返します
+func (s *Scheduler) AddSystem(stage Stage, name string, system System, opts ...SystemOption) error {
+	if stage < 0 || stage >= stageCount {
+		return fmt.Errorf("%w: %v", ErrUnknownStage, stage)
+	}
+	if _, ok := s.systems[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateSystem, name)
+	}
+
+	entry := &systemEntry{
+		name:   name,
+		stage:  stage,
+		system: system,
+	}
+	if declarer, ok := system.(AccessDeclarer); ok {
+		access := declarer.Access()
+		entry.access = &access
+	}
+	for _, opt := range opts {
+		opt(entry)
+	}
+	s.systems[name] = entry
+	s.stages[stage] = append(s.stages[stage], entry)
+	s.built = false
+	return nil
+}
+
+// Build : Before, Afterの指定に従ってStage毎の実行順を確定します
+// 指定に循環がある場合はErrSystemCycle、存在しないSystemを指定した場合はErrUnknownSystemを返します
+func (s *Scheduler) Build() error {
+	if s.built {
+		return nil
+	}
+
+	for stage := range s.stages {
+		entries := s.stages[stage]
+		for _, e := range entries {
+			e.deps = e.deps[:0]
+		}
+		for _, e := range entries {
+			for _, name := range e.after {
+				dep, err := s.lookup(e, name)
+				if err != nil {
+					return err
+				}
+				if dep != nil {
+					e.deps = append(e.deps, dep)
+				}
+			}
+			for _, name := range e.before {
+				next, err := s.lookup(e, name)
+				if err != nil {
+					return err
+				}
+				if next != nil {
+					next.deps = append(next.deps, e)
+				}
+			}
+		}
+
+		order, err := sortSystems(entries)
+		if err != nil {
+			return err
+		}
+		s.orders[stage] = order
+		s.graphs[stage] = newSystemGraph(order)
+	}
+	s.built = true
+	return nil
+}
+
+// lookup : 順序指定に利用されたSystemを取得します. 別のStageに登録されたSystemの場合はnilを返します
+func (s *Scheduler) lookup(from *systemEntry, name string) (*systemEntry, error) {
+	entry, ok := s.systems[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (referenced by %s)", ErrUnknownSystem, name, from.name)
+	}
+	if entry.stage != from.stage {
+		return nil, nil
+	}
+	return entry, nil
+}
+
+// Update : Systemを実行します
+// 最初の呼び出し時はBuildを行った上で、Startupに登録されたSystemを実行します
+func (s *Scheduler) Update(w *World, dt time.Duration) error {
+	if err := s.Build(); err != nil {
+		return err
+	}
+
+	if !s.started {
+		s.runStage(w, StageStartup, dt)
+		s.started = true
+	}
+	for stage := StagePreUpdate; stage < stageCount; stage++ {
+		s.runStage(w, stage, dt)
+	}
+	return nil
+}
+
+// runStage : 指定したStageのSystemを実行順に実行します
+// Stageの終了時に、WorldのCommandBufferに記録された操作を適用し、必要であれば削除の記録を入れ替えます
+func (s *Scheduler) runStage(w *World, stage Stage, dt time.Duration) {
+	if s.workers > 1 && len(s.orders[stage]) > 1 {
+		s.graphs[stage].run(w, dt, s.workers)
+	} else {
+		for _, e := range s.orders[stage] {
+			w.AdvanceTick()
+			e.system.Update(w, dt)
+		}
+	}
+	// CommandBufferによる変更も、Systemによる変更と区別できるようにTickを進めてから適用する
+	w.AdvanceTick()
+	w.Apply(w.Commands())
+	if stage == s.removedClearStage {
+		w.ClearRemoved()
+	}
+}
+
+// newSystemGraph : 実行順に並んだSystemから並列実行用の依存グラフを生成します
+// 順序指定による依存に加えて、先に実行されるSystemと競合する場合もそのSystemに依存させます
+func newSystemGraph(order []*systemEntry) systemGraph {
+	positions := make(map[*systemEntry]int, len(order))
+	for i, e := range order {
+		positions[e] = i
+	}
+
+	g := systemGraph{
+		systems:    order,
+		dependents: make([][]int, len(order)),
+		indegrees:  make([]int32, len(order)),
+	}
+	for j, e := range order {
+		deps := make(map[int]struct{}, len(e.deps))
+		for _, dep := range e.deps {
+			deps[positions[dep]] = struct{}{}
+		}
+		for i := 0; i < j; i++ {
+			if order[i].conflicts(e) {
+				deps[i] = struct{}{}
+			}
+		}
+		for i := 0; i < j; i++ {
+			if _, ok := deps[i]; ok {
+				g.dependents[i] = append(g.dependents[i], j)
+				g.indegrees[j]++
+			}
+		}
+	}
+	return g
+}
+
+// systemGraph : 並列実行用のSystemの依存グラフ
+type systemGraph struct {
+	systems    []*systemEntry
+	dependents [][]int // Indexに対応するSystemの完了を待っているSystem
+	indegrees  []int32 // Indexに対応するSystemが完了を待つSystemの数
+}
+
+// run : 依存しているSystemが全て完了したものから順に、goroutineで並列に実行します
+// Systemがpanicした場合は残りのSystemを実行せず、全てのgoroutineの終了を待ってから呼び出し元のgoroutineでpanicし直します
+func (g *systemGraph) run(w *World, dt time.Duration, workers int) {
+	n := len(g.systems)
+	remaining := make([]int32, n)
+	copy(remaining, g.indegrees)
+
+	ready := make(chan int, n)
+	done := make(chan int, n)
+	for i := range remaining {
+		if remaining[i] == 0 {
+			ready <- i
+		}
+	}
+
+	var (
+		failed    atomic.Bool
+		recovered any       // 最初にpanicしたSystemのpanicの値
+		once      sync.Once // recoveredを1度だけ記録するためのOnce
+	)
+	update := func(i int) {
+		defer func() {
+			if r := recover(); r != nil {
+				once.Do(func() { recovered = r })
+				failed.Store(true)
+			}
+		}()
+		// 先に実行したSystemがpanicしている場合は、以降のSystemは実行しない
+		if failed.Load() {
+			return
+		}
+		w.AdvanceTick()
+		g.systems[i].system.Update(w, dt)
+	}
+
+	wg := sync.WaitGroup{}
+	for range min(workers, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range ready {
+				// panicした場合もdoneに送り、待ちが残らないようにする
+				update(i)
+				done <- i
+			}
+		}()
+	}
+
+	// 完了したSystemに依存しているSystemのうち、待ちがなくなったものを実行可能にする
+	for completed := 0; completed < n; completed++ {
+		i := <-done
+		for _, j := range g.dependents[i] {
+			remaining[j]--
+			if remaining[j] == 0 {
+				ready <- j
+			}
+		}
+	}
+	close(ready)
+	wg.Wait()
+	if failed.Load() {
+		panic(recovered)
+	}
+}
+
+// sortSystems : 依存関係に従ってSystemをトポロジカルソートします
+// 依存関係のないSystem同士は登録順を維持します
+func sortSystems(entries []*systemEntry) ([]*systemEntry, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	order := make([]*systemEntry, 0, len(entries))
+	states := make(map[*systemEntry]int, len(entries))
+	stack := make([]*systemEntry, 0, len(entries))
+
+	var visit func(e *systemEntry) error
+	visit = func(e *systemEntry) error {
+		switch states[e] {
+		case visited:
+			return nil
+		case visiting:
+			// stack上のeから現在地までが循環している. 実行順（先に実行するもの -> 後に実行するもの）で表示する
+			names := []string{e.name}
+			for i := len(stack) - 1; stack[i] != e; i-- {
+				names = append(names, stack[i].name)
+			}
+			names = append(names, e.name)
+			return fmt.Errorf("%w: %s", ErrSystemCycle, strings.Join(names, " -> "))
+		}
+
+		states[e] = visiting
+		stack = append(stack, e)
+		for _, dep := range e.deps {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		states[e] = visited
+		order = append(order, e)
+		return nil
+	}
+
+	for _, e := range entries {
+		if err := visit(e); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
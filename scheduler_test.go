@@ -0,0 +1,236 @@
+package ecsbit
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduler_Update(t *testing.T) {
+	recorder := func(log *[]string, name string) System {
+		return SystemFunc(func(w *World, dt time.Duration) {
+			*log = append(*log, name)
+		})
+	}
+
+	t.Run("stage order and startup once", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		log := []string{}
+		s := NewScheduler()
+		_ = s.AddSystem(StagePostUpdate, "post", recorder(&log, "post"))
+		_ = s.AddSystem(StageUpdate, "update", recorder(&log, "update"))
+		_ = s.AddSystem(StagePreUpdate, "pre", recorder(&log, "pre"))
+		_ = s.AddSystem(StageStartup, "startup", recorder(&log, "startup"))
+
+		// act
+		if err := s.Update(w, time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := s.Update(w, time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// assert
+		want := "startup,pre,update,post,pre,update,post"
+		if got := strings.Join(log, ","); got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("before and after", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		log := []string{}
+		s := NewScheduler()
+		_ = s.AddSystem(StageUpdate, "render", recorder(&log, "render"), After("physics"))
+		_ = s.AddSystem(StageUpdate, "physics", recorder(&log, "physics"))
+		_ = s.AddSystem(StageUpdate, "input", recorder(&log, "input"), Before("physics"))
+
+		// act
+		if err := s.Update(w, time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// assert
+		want := "input,physics,render"
+		if got := strings.Join(log, ","); got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		// arrange
+		s := NewScheduler()
+		noop := SystemFunc(func(w *World, dt time.Duration) {})
+		_ = s.AddSystem(StageUpdate, "a", noop, After("c"))
+		_ = s.AddSystem(StageUpdate, "b", noop, After("a"))
+		_ = s.AddSystem(StageUpdate, "c", noop, After("b"))
+
+		// act
+		err := s.Build()
+
+		// assert
+		if !errors.Is(err, ErrSystemCycle) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(err.Error(), "a -> b -> c -> a") {
+			t.Errorf("unexpected error message: %v", err)
+		}
+	})
+
+	t.Run("invalid registration", func(t *testing.T) {
+		// arrange
+		s := NewScheduler()
+		noop := SystemFunc(func(w *World, dt time.Duration) {})
+
+		// act & assert
+		if err := s.AddSystem(StageUpdate, "a", noop, After("missing")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := s.AddSystem(StageUpdate, "a", noop); !errors.Is(err, ErrDuplicateSystem) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := s.AddSystem(Stage(10), "b", noop); !errors.Is(err, ErrUnknownStage) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := s.Build(); !errors.Is(err, ErrUnknownSystem) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestScheduler_Parallel(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+
+	t.Run("non-conflicting systems run concurrently", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+
+		// 2つのSystemが互いの開始を待つため、直列に実行されるとタイムアウトする
+		started := [2]chan struct{}{make(chan struct{}), make(chan struct{})}
+		failed := make(chan struct{}, 2)
+		waiter := func(self, other int) System {
+			return SystemFunc(func(w *World, dt time.Duration) {
+				close(started[self])
+				select {
+				case <-started[other]:
+				case <-time.After(time.Second):
+					failed <- struct{}{}
+				}
+			})
+		}
+
+		s := NewScheduler(WithParallel(2))
+		_ = s.AddSystem(StageUpdate, "a", waiter(0, 1), WithAccess(NewAccess().Write(posID)))
+		_ = s.AddSystem(StageUpdate, "b", waiter(1, 0), WithAccess(NewAccess().Write(velID)))
+
+		// act
+		if err := s.Update(w, time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// assert
+		if len(failed) != 0 {
+			t.Errorf("expected systems run concurrently, but serialized")
+		}
+	})
+
+	t.Run("conflicting systems keep declared order", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+
+		mu := sync.Mutex{}
+		log := []string{}
+		recorder := func(name string) System {
+			return SystemFunc(func(w *World, dt time.Duration) {
+				// 後続のSystemが先に実行されてしまう状況を作りやすくするために待つ
+				time.Sleep(5 * time.Millisecond)
+				mu.Lock()
+				defer mu.Unlock()
+				log = append(log, name)
+			})
+		}
+
+		s := NewScheduler(WithParallel(4))
+		_ = s.AddSystem(StageUpdate, "writer", recorder("writer"), WithAccess(NewAccess().Write(posID)))
+		_ = s.AddSystem(StageUpdate, "reader", recorder("reader"), WithAccess(NewAccess().Read(posID, velID)))
+		_ = s.AddSystem(StageUpdate, "exclusive", recorder("exclusive"))
+
+		// act
+		for i := 0; i < 3; i++ {
+			if err := s.Update(w, time.Millisecond); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		// assert
+		want := strings.Repeat("writer,reader,exclusive,", 3)
+		if got := strings.Join(log, ",") + ","; got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("panic in system is raised on caller", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+
+		s := NewScheduler(WithParallel(2))
+		_ = s.AddSystem(StageUpdate, "panic", SystemFunc(func(w *World, dt time.Duration) {
+			panic("boom")
+		}), WithAccess(NewAccess().Write(posID)))
+		_ = s.AddSystem(StageUpdate, "other", SystemFunc(func(w *World, dt time.Duration) {}), WithAccess(NewAccess().Write(velID)))
+
+		// act
+		var recovered any
+		func() {
+			defer func() { recovered = recover() }()
+			_ = s.Update(w, time.Millisecond)
+		}()
+
+		// assert
+		if recovered != "boom" {
+			t.Errorf("unexpected result: got %v, want %v", recovered, "boom")
+		}
+	})
+}
+
+func TestAccess_Conflicts(t *testing.T) {
+	testcases := []struct {
+		title string
+		a, b  Access
+		want  bool
+	}{
+		{title: "read read", a: NewAccess().Read(1), b: NewAccess().Read(1), want: false},
+		{title: "read write", a: NewAccess().Read(1), b: NewAccess().Write(1), want: true},
+		{title: "write read", a: NewAccess().Write(1), b: NewAccess().Read(1), want: true},
+		{title: "write write", a: NewAccess().Write(1), b: NewAccess().Write(1), want: true},
+		{title: "disjoint", a: NewAccess().Write(1), b: NewAccess().Write(2).Read(3), want: false},
+		{title: "resource read read", a: NewAccess().ReadResource(1), b: NewAccess().ReadResource(1), want: false},
+		{title: "resource read write", a: NewAccess().ReadResource(1), b: NewAccess().WriteResource(1), want: true},
+		{title: "resource write write", a: NewAccess().WriteResource(1), b: NewAccess().WriteResource(1), want: true},
+		{title: "resource and component", a: NewAccess().WriteResource(1), b: NewAccess().Write(1), want: false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.title, func(t *testing.T) {
+			// act & assert
+			if got := tc.a.Conflicts(&tc.b); got != tc.want {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+package ecsbit
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"reflect"
+	"unsafe"
+
+	"github.com/atEaE/ecsbit/config"
+)
+
+const (
+	// snapshotMagic : Snapshotの先頭に書き込む識別子
+	snapshotMagic = "ECSB"
+	// snapshotVersion : Snapshotのフォーマットのバージョン（フォーマットを変更した場合は更新する）
+	snapshotVersion uint16 = 1
+)
+
+// snapshotHeader : Snapshotに含まれるWorldの構造
+// Componentのデータは、Headerの後にArchetype、Componentの順で列毎にエンコードする
+type snapshotHeader struct {
+	Components []snapshotComponent // ComponentIDの順に並べた登録済みのComponent
+	Entities   []Entity            // entityPool.entities（リサイクル待ちのEntityのversionを含む）
+	Next       EntityID            // entityPool.next
+	Available  uint32              // entityPool.available
+	Archetypes []snapshotArchetype // Entityが所属しているArchetype
+}
+
+// snapshotComponent : Snapshotに含まれるComponentの情報
+type snapshotComponent struct {
+	Name     string           // component.Name()
+	Type     string           // 型の文字列表現（読み込み時に型が一致しているかの確認に利用する）
+	Size     uintptr          // 型のサイズ（メモリをそのままコピーする場合に、読み込み時の型と一致しているかの確認に利用する）
+	Encoding snapshotEncoding // Componentのデータのエンコード方法
+}
+
+// snapshotEncoding : Componentのデータのエンコード方法
+type snapshotEncoding uint8
+
+const (
+	encodingNone snapshotEncoding = iota // データを持たないため書き込まない（サイズが0の型）
+	encodingRaw                          // メモリをそのままコピーする（ポインタを含まない型）
+	encodingGob                          // encoding/gobでエンコードする（ポインタを含み、全てのフィールドが公開されている型）
+)
+
+// snapshotArchetype : Snapshotに含まれるArchetypeの情報
+type snapshotArchetype struct {
+	Components []ComponentID // Layoutに含まれるComponentID（昇順）
+	Entities   []Entity      // Archetypeに属するEntity
+	Targets    [][]Entity    // Relation毎のEntityのTarget（Componentsに含まれるRelationの順）
+}
+
+// Save : Worldの状態をバイナリ形式で書き込みます
+// 登録済みのComponent、Entity Poolの状態、全てのArchetypeとComponentのデータが対象です.
+// Hook、コールバック、Observer、Resource、CommandBufferに積まれたコマンドは対象外です.
+// CommandBufferで予約したEntityは保存できないため、適用前の場合は何も書き込まずにErrReservedEntitiesを返します.
+// ポインタを含まないComponentはメモリをそのままコピーするため、非公開のフィールドも保存されます（読み込めるのは同じアーキテクチャのみです）.
+// ポインタを含むComponentはencoding/gobでエンコードするため、全てのフィールドが公開されている必要があります.
+// 非公開のフィールドや、関数、チャネル、interfaceを含むComponentのEntityがある場合は、何も書き込まずにErrUnserializableComponentを返します
+func (w *World) Save(writer io.Writer) error {
+	// 予約済みのEntityを保存しないと、読み込み後に同じEntityIDが別のEntityとして再利用されてしまう
+	if w.entityPool.Reserved() > 0 {
+		return ErrReservedEntities
+	}
+	header := snapshotHeader{
+		Components: make([]snapshotComponent, len(w.componentStorage.IDs)),
+		Entities:   w.entityPool.entities,
+		Next:       w.entityPool.next,
+		Available:  w.entityPool.available,
+	}
+	errs := make([]error, len(w.componentStorage.IDs))
+	for i, id := range w.componentStorage.IDs {
+		c := w.componentStorage.Types[id]
+		encoding, err := snapshotEncodingOf(c.Type())
+		header.Components[i] = snapshotComponent{Name: c.Name(), Type: c.Type().String(), Size: c.Type().Size(), Encoding: encoding}
+		errs[i] = err
+	}
+	archetypes := make([]*archetype, 0, len(w.archetypes))
+	for _, a := range w.archetypes {
+		if a.Count() == 0 {
+			continue
+		}
+		// 保存できないComponentは、Entityが持っている場合のみエラーにする
+		for _, id := range a.componentIDs {
+			if errs[id] != nil {
+				return errs[id]
+			}
+		}
+		archetypes = append(archetypes, a)
+		sa := snapshotArchetype{
+			Components: a.componentIDs,
+			Entities:   a.entities,
+		}
+		for i := range a.columns {
+			if c := &a.columns[i]; c.targets != nil {
+				sa.Targets = append(sa.Targets, c.targets[:c.len])
+			}
+		}
+		header.Archetypes = append(header.Archetypes, sa)
+	}
+
+	if _, err := io.WriteString(writer, snapshotMagic); err != nil {
+		return err
+	}
+	if err := binary.Write(writer, binary.LittleEndian, snapshotVersion); err != nil {
+		return err
+	}
+	encoder := gob.NewEncoder(writer)
+	if err := encoder.Encode(&header); err != nil {
+		return err
+	}
+	for _, a := range archetypes {
+		for i, id := range a.componentIDs {
+			c := &a.columns[i]
+			var err error
+			switch header.Components[id].Encoding {
+			case encodingNone:
+				// サイズが0のComponent（タグ）はデータを持たないので書き込まない
+				continue
+			case encodingRaw:
+				err = encoder.Encode(unsafe.Slice((*byte)(c.pointer), uintptr(c.len)*c.itemSize))
+			case encodingGob:
+				err = encoder.EncodeValue(c.data.Slice(0, int(c.len)))
+			}
+			if err != nil {
+				return fmt.Errorf("failed to encode component %s: %w", c.typ, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Load : Saveで書き込んだ状態からWorldを生成します
+// componentsには、Snapshotに含まれるComponentをNewComponentで生成して指定してください.
+// Componentは名前で照合し、ComponentIDが保存時と一致するように登録されます（ChildOfは指定しなくても照合されます）.
+// Snapshotに含まれないComponentは、Snapshotに含まれるComponentの後に登録されます
+func Load(reader io.Reader, components []component, opts ...config.WorldConfigOption) (*World, error) {
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(reader, magic); err != nil {
+		return nil, err
+	}
+	var version uint16
+	if err := binary.Read(reader, binary.LittleEndian, &version); err != nil {
+		return nil, err
+	}
+	if string(magic) != snapshotMagic || version != snapshotVersion {
+		return nil, ErrInvalidSnapshot
+	}
+
+	decoder := gob.NewDecoder(reader)
+	var header snapshotHeader
+	if err := decoder.Decode(&header); err != nil {
+		return nil, err
+	}
+	if len(header.Entities) == 0 || header.Entities[0] != zeroEntity {
+		return nil, ErrInvalidSnapshot
+	}
+
+	w := NewWorld(opts...)
+	if err := w.registerSnapshotComponents(header.Components, components); err != nil {
+		return nil, err
+	}
+
+	w.entityPool.entities = append(w.entityPool.entities[:0], header.Entities...)
+	w.entityPool.next, w.entityPool.available = header.Next, header.Available
+	w.setEntityIndex(EntityID(len(header.Entities)-1), EntityIndex{})
+
+	for _, sa := range header.Archetypes {
+		for _, id := range sa.Components {
+			if int(id) >= len(header.Components) {
+				return nil, ErrInvalidSnapshot
+			}
+		}
+		a := w.findOrCreateArchetypeByLayout(createLayoutMask(sa.Components))
+		for _, e := range sa.Entities {
+			if !w.entityPool.Alive(e) || w.entityIndices[e.ID()].archetype != nil {
+				return nil, ErrInvalidSnapshot
+			}
+			w.entityIndices[e.ID()] = EntityIndex{index: a.Add(e, w.Tick()), archetype: a}
+		}
+		targets := sa.Targets
+		for i, id := range a.componentIDs {
+			c := &a.columns[i]
+			if c.targets != nil {
+				// Targetは全てのEntityを生成してから検証し、逆引きに追加する
+				if len(targets) == 0 || len(targets[0]) != len(sa.Entities) {
+					return nil, ErrInvalidSnapshot
+				}
+				copy(c.targets, targets[0])
+				targets = targets[1:]
+			}
+			switch header.Components[id].Encoding {
+			case encodingRaw:
+				var raw []byte
+				if err := decoder.Decode(&raw); err != nil {
+					return nil, fmt.Errorf("failed to decode component %s: %w", c.typ, err)
+				}
+				if uintptr(len(raw)) != uintptr(len(sa.Entities))*c.itemSize {
+					return nil, ErrInvalidSnapshot
+				}
+				copy(unsafe.Slice((*byte)(c.pointer), len(raw)), raw)
+			case encodingGob:
+				data := reflect.New(c.data.Type())
+				if err := decoder.DecodeValue(data); err != nil {
+					return nil, fmt.Errorf("failed to decode component %s: %w", c.typ, err)
+				}
+				if data.Elem().Len() != len(sa.Entities) {
+					return nil, ErrInvalidSnapshot
+				}
+				reflect.Copy(c.data, data.Elem())
+			}
+		}
+		if len(targets) != 0 {
+			return nil, ErrInvalidSnapshot
+		}
+	}
+	if err := w.linkSnapshotTargets(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// linkSnapshotTargets : 読み込んだRelationのTargetを検証し、Targetの逆引きに追加します
+func (w *World) linkSnapshotTargets() error {
+	for _, a := range w.archetypes {
+		for i, id := range a.componentIDs {
+			c := &a.columns[i]
+			if c.targets == nil {
+				continue
+			}
+			for j, target := range c.targets[:c.len] {
+				if target == noTarget {
+					continue
+				}
+				if !w.Alive(target) {
+					return fmt.Errorf("%w: invalid target of %v", ErrInvalidSnapshot, a.GetEntity(uint32(j)))
+				}
+				w.linkTarget(a.GetEntity(uint32(j)), id, target)
+			}
+		}
+	}
+	return nil
+}
+
+// registerSnapshotComponents : Snapshotに含まれるComponentを、保存時と同じComponentIDになるように登録します
+func (w *World) registerSnapshotComponents(saved []snapshotComponent, components []component) error {
+	byName := make(map[string]component, len(components))
+	for _, c := range components {
+		byName[c.Name()] = c
+	}
+	registered := make(map[string]bool, len(saved))
+	for _, sc := range saved {
+		c, ok := byName[sc.Name]
+		if !ok && sc.Type == childOfType.String() {
+			c, ok = NewComponent[ChildOf](), true
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s is not specified", ErrSnapshotComponentMismatch, sc.Name)
+		}
+		if c.Type().String() != sc.Type {
+			return fmt.Errorf("%w: %s is %s, but snapshot has %s", ErrSnapshotComponentMismatch, sc.Name, c.Type(), sc.Type)
+		}
+		// 型の文字列表現が同じでも、フィールドの構成が変わっているとデータを読み込めない
+		if encoding, _ := snapshotEncodingOf(c.Type()); encoding != sc.Encoding || c.Type().Size() != sc.Size {
+			return fmt.Errorf("%w: layout of %s has changed", ErrSnapshotComponentMismatch, sc.Name)
+		}
+		w.RegisterComponent(c)
+		registered[sc.Name] = true
+	}
+	for _, c := range components {
+		if !registered[c.Name()] {
+			w.RegisterComponent(c)
+		}
+	}
+	return nil
+}
+
+var (
+	// gobEncoderType : gob.GobEncoderの型情報
+	gobEncoderType = reflect.TypeFor[gob.GobEncoder]()
+	// binaryMarshalerType : encoding.BinaryMarshalerの型情報
+	binaryMarshalerType = reflect.TypeFor[encoding.BinaryMarshaler]()
+)
+
+// snapshotEncodingOf : Componentの型から、データのエンコード方法を決定します
+// 欠損なく保存できない型の場合はErrUnserializableComponentを返します
+func snapshotEncodingOf(typ reflect.Type) (snapshotEncoding, error) {
+	switch {
+	case typ.Size() == 0:
+		return encodingNone, nil
+	case isPointerFree(typ):
+		return encodingRaw, nil
+	}
+	if err := checkGobEncodable(typ, typ.String(), map[reflect.Type]bool{}); err != nil {
+		return encodingGob, fmt.Errorf("%w: %w", ErrUnserializableComponent, err)
+	}
+	return encodingGob, nil
+}
+
+// isPointerFree : ポインタを含まない型かどうかを返します
+// ポインタを含まない型は、メモリをそのままコピーしても値が変わらない
+func isPointerFree(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return typ.Len() == 0 || isPointerFree(typ.Elem())
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			if !isPointerFree(typ.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// checkGobEncodable : encoding/gobで欠損なくエンコードできる型かどうかを検証します
+// pathはエラーに含めるフィールドまでの経路、visitedは再帰的な型を検証済みとして扱うために利用します
+func checkGobEncodable(typ reflect.Type, path string, visited map[reflect.Type]bool) error {
+	if visited[typ] {
+		return nil
+	}
+	visited[typ] = true
+	// 独自にエンコードする型は、その実装に任せる
+	if typ.Implements(gobEncoderType) || typ.Implements(binaryMarshalerType) ||
+		reflect.PointerTo(typ).Implements(gobEncoderType) || reflect.PointerTo(typ).Implements(binaryMarshalerType) {
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return fmt.Errorf("%s is %s", path, typ.Kind())
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return checkGobEncodable(typ.Elem(), path, visited)
+	case reflect.Map:
+		if err := checkGobEncodable(typ.Key(), path, visited); err != nil {
+			return err
+		}
+		return checkGobEncodable(typ.Elem(), path, visited)
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			fieldPath := path + "." + field.Name
+			// サイズが0のフィールドは値を持たないので、欠損しても問題ない
+			if field.Type.Size() == 0 {
+				continue
+			}
+			if !field.IsExported() {
+				return fmt.Errorf("%s is unexported", fieldPath)
+			}
+			if err := checkGobEncodable(field.Type, fieldPath, visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
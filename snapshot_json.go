@@ -0,0 +1,166 @@
+package ecsbit
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+
+	"github.com/atEaE/ecsbit/config"
+)
+
+// jsonWorld : JSON形式で出力するWorldの状態
+type jsonWorld struct {
+	Entities []jsonEntity `json:"entities"` // 生存しているEntity（EntityIDの昇順）
+}
+
+// jsonEntity : JSON形式で出力するEntityの状態
+type jsonEntity struct {
+	ID         EntityID                   `json:"id"`
+	Version    uint32                     `json:"version"`
+	Relations  map[string]jsonTarget      `json:"relations,omitempty"` // Relationの名前をキーとしたTarget（未設定のRelationは省略する）
+	Components map[string]json.RawMessage `json:"components"`          // Componentの名前をキーとしたComponentの値
+}
+
+// jsonTarget : JSON形式で出力するRelationのTarget
+type jsonTarget struct {
+	ID      EntityID `json:"id"`
+	Version uint32   `json:"version"`
+}
+
+// MarshalJSON : Worldの状態をJSON形式で出力します
+// 生存しているEntity毎に、IDとversion、Componentの名前をキーとしたComponentの値を出力します.
+// Componentの値はencoding/jsonでエンコードするため、Componentの名前はWorld内で一意にしてください.
+// CommandBufferで予約したEntityが適用前の場合は、ErrReservedEntitiesを返します
+func (w *World) MarshalJSON() ([]byte, error) {
+	if w.entityPool.Reserved() > 0 {
+		return nil, ErrReservedEntities
+	}
+	out := jsonWorld{Entities: make([]jsonEntity, 0, w.entityPool.Used())}
+	for _, a := range w.archetypes {
+		for i, e := range a.entities {
+			je := jsonEntity{
+				ID:         e.ID(),
+				Version:    e.Version(),
+				Components: make(map[string]json.RawMessage, len(a.columns)),
+			}
+			for j, id := range a.componentIDs {
+				c := &a.columns[j]
+				name := w.componentStorage.Types[id].Name()
+				if c.targets != nil && c.targets[i] != noTarget {
+					if je.Relations == nil {
+						je.Relations = make(map[string]jsonTarget)
+					}
+					je.Relations[name] = jsonTarget{ID: c.targets[i].ID(), Version: c.targets[i].Version()}
+				}
+				b, err := json.Marshal(reflect.NewAt(c.typ, c.Get(uint32(i))).Interface())
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode component %s: %w", c.typ, err)
+				}
+				je.Components[name] = b
+			}
+			out.Entities = append(out.Entities, je)
+		}
+	}
+	slices.SortFunc(out.Entities, func(a, b jsonEntity) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return json.Marshal(&out)
+}
+
+// LoadJSON : MarshalJSONで出力した状態からWorldを生成します
+// componentsには、JSONに含まれるComponentをNewComponentで生成して指定してください. Componentは名前で照合します（ChildOfは指定しなくても照合されます）.
+// EntityのIDとversionは出力時の値が維持されますが、リサイクル待ちのEntityのversionは維持されません.
+// 完全な状態を復元する必要がある場合は、Save、Loadを利用してください
+func LoadJSON(reader io.Reader, components []component, opts ...config.WorldConfigOption) (*World, error) {
+	var in jsonWorld
+	if err := json.NewDecoder(reader).Decode(&in); err != nil {
+		return nil, err
+	}
+
+	w := NewWorld(opts...)
+	byName := make(map[string]ComponentID, len(components))
+	for _, c := range components {
+		byName[c.Name()] = w.RegisterComponent(c)
+	}
+	componentIDOfName := func(name string) (ComponentID, error) {
+		if id, ok := byName[name]; ok {
+			return id, nil
+		}
+		if name == childOfType.Name() {
+			byName[name] = w.childOfID()
+			return byName[name], nil
+		}
+		return 0, fmt.Errorf("%w: %s is not specified", ErrSnapshotComponentMismatch, name)
+	}
+
+	if err := w.restoreEntityPool(in.Entities); err != nil {
+		return nil, err
+	}
+
+	for _, je := range in.Entities {
+		e := NewEntity(je.ID) | Entity(je.Version)
+		ids := make([]ComponentID, 0, len(je.Components))
+		for name := range je.Components {
+			id, err := componentIDOfName(name)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+
+		a := w.findOrCreateArchetype(ids)
+		index := a.Add(e, w.Tick())
+		w.setEntityIndex(e.ID(), EntityIndex{index: index, archetype: a})
+		for name, raw := range je.Components {
+			id := byName[name]
+			c := a.Column(id)
+			if err := json.Unmarshal(raw, reflect.NewAt(c.typ, c.Get(index)).Interface()); err != nil {
+				return nil, fmt.Errorf("failed to decode component %s of %v: %w", name, e, err)
+			}
+		}
+	}
+
+	// Targetは全てのEntityを生成してから設定する
+	for _, je := range in.Entities {
+		e := NewEntity(je.ID) | Entity(je.Version)
+		for name, t := range je.Relations {
+			id, ok := byName[name]
+			target := NewEntity(t.ID) | Entity(t.Version)
+			if !ok || !w.componentStorage.IsRelation(id) || !w.entityIndices[e.ID()].archetype.HasComponent(id) || !w.Alive(target) {
+				return nil, fmt.Errorf("%w: invalid target of %v", ErrInvalidSnapshot, e)
+			}
+			w.SetRelation(e, id, target)
+		}
+	}
+	return w, nil
+}
+
+// restoreEntityPool : 生存しているEntityのIDとversionからEntity Poolを復元します
+// 含まれていないEntityIDは、リサイクル待ちのEntityとして扱います
+func (w *World) restoreEntityPool(entities []jsonEntity) error {
+	maxID := EntityID(0)
+	alive := make(map[EntityID]uint32, len(entities))
+	for _, je := range entities {
+		if _, ok := alive[je.ID]; ok || je.ID == 0 {
+			return fmt.Errorf("%w: invalid entity id %d", ErrInvalidSnapshot, je.ID)
+		}
+		alive[je.ID] = je.Version
+		maxID = max(maxID, je.ID)
+	}
+
+	p := &w.entityPool
+	for id := EntityID(1); id <= maxID; id++ {
+		p.entities = append(p.entities, NewEntity(id)|Entity(alive[id]))
+	}
+	// 小さいEntityIDから再利用されるように、大きいEntityIDからリサイクルする
+	for id := maxID; id > 0; id-- {
+		if _, ok := alive[id]; !ok {
+			p.Recycle(p.entities[id])
+		}
+	}
+	w.setEntityIndex(maxID, EntityIndex{})
+	return nil
+}
@@ -0,0 +1,118 @@
+package ecsbit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWorld_MarshalJSON(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Frozen struct{}
+
+	components := func() []component {
+		return []component{NewComponent[Position](), NewComponent[Frozen]()}
+	}
+
+	t.Run("export", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		e := w.CreateEntity(posID)
+		Set(w, e, Position{X: 1, Y: 2})
+
+		// act
+		b, err := json.Marshal(w)
+
+		// assert
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"entities":[{"id":1,"version":0,"components":{"Position":{"X":1,"Y":2}}}]}`
+		if got := string(b); got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		for _, c := range components() {
+			w.RegisterComponent(c)
+		}
+		parent := w.CreateEntity(0)
+		Set(w, parent, Position{X: 1, Y: 2})
+		w.RemoveEntity(w.CreateEntity())
+		child := w.CreateEntity(1)
+		w.SetParent(child, parent)
+		b, err := json.Marshal(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// act
+		loaded, err := LoadJSON(bytes.NewReader(b), components())
+
+		// assert
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !loaded.Alive(parent) || !loaded.Alive(child) {
+			t.Fatalf("entities should be alive")
+		}
+		if got := Get[Position](loaded, parent); *got != (Position{X: 1, Y: 2}) {
+			t.Errorf("unexpected result: got %v, want %v", *got, Position{X: 1, Y: 2})
+		}
+		if got := loaded.Parent(child); got != parent {
+			t.Errorf("unexpected result: got %v, want %v", got, parent)
+		}
+		reloaded, err := json.Marshal(loaded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(reloaded, b) {
+			t.Errorf("unexpected result: got %s, want %s", reloaded, b)
+		}
+	})
+
+	t.Run("hand edited", func(t *testing.T) {
+		// arrange
+		in := `{"entities":[
+			{"id":3,"version":2,"components":{"Position":{"X":5}}},
+			{"id":1,"version":0,"components":{"Frozen":{}}}
+		]}`
+
+		// act
+		w, err := LoadJSON(strings.NewReader(in), components())
+
+		// assert
+		if err != nil {
+			t.Fatal(err)
+		}
+		e := NewEntity(3) | Entity(2)
+		if got := Get[Position](w, e); got.X != 5 {
+			t.Errorf("unexpected result: got %v, want %v", got.X, 5)
+		}
+		// 含まれていないEntityIDから再利用される
+		if got := w.CreateEntity().ID(); got != 2 {
+			t.Errorf("unexpected result: got %v, want %v", got, 2)
+		}
+	})
+
+	t.Run("unknown component", func(t *testing.T) {
+		// arrange
+		in := `{"entities":[{"id":1,"version":0,"components":{"Velocity":{}}}]}`
+
+		// act
+		_, err := LoadJSON(strings.NewReader(in), components())
+
+		// assert
+		if !errors.Is(err, ErrSnapshotComponentMismatch) {
+			t.Errorf("unexpected result: got %v, want %v", err, ErrSnapshotComponentMismatch)
+		}
+	})
+}
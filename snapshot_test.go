@@ -0,0 +1,227 @@
+package ecsbit
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestWorld_SaveLoad(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Name struct {
+		Value string
+	}
+	type Frozen struct{}
+
+	components := func() []component {
+		return []component{NewComponent[Position](), NewComponent[Name](), NewComponent[Frozen]()}
+	}
+	setup := func() (*World, []Entity) {
+		w := NewWorld()
+		for _, c := range components() {
+			w.RegisterComponent(c)
+		}
+		posID, nameID, frozenID := ComponentID(0), ComponentID(1), ComponentID(2)
+		parent := w.CreateEntity(posID, nameID)
+		Set(w, parent, Position{X: 1, Y: 2})
+		Set(w, parent, Name{Value: "parent"})
+		child := w.CreateEntity(posID, frozenID)
+		Set(w, child, Position{X: 3, Y: 4})
+		w.SetParent(child, parent)
+		// リサイクル済みのEntityを作って、versionが保存されることを確認する
+		w.RemoveEntity(w.CreateEntity(posID))
+		recycled := w.CreateEntity(nameID)
+		Set(w, recycled, Name{Value: "recycled"})
+		return w, []Entity{parent, child, recycled}
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		// arrange
+		w, entities := setup()
+		parent, child, recycled := entities[0], entities[1], entities[2]
+		var buf bytes.Buffer
+		if err := w.Save(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		// act
+		loaded, err := Load(&buf, components())
+
+		// assert
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range entities {
+			if !loaded.Alive(e) {
+				t.Errorf("entity %v should be alive", e)
+			}
+		}
+		if recycled.Version() == 0 {
+			t.Errorf("recycled entity should have a new version")
+		}
+		if got := Get[Position](loaded, child); *got != (Position{X: 3, Y: 4}) {
+			t.Errorf("unexpected result: got %v, want %v", *got, Position{X: 3, Y: 4})
+		}
+		if got := Get[Name](loaded, recycled); got.Value != "recycled" {
+			t.Errorf("unexpected result: got %v, want %v", got.Value, "recycled")
+		}
+		if !Has[Frozen](loaded, child) {
+			t.Errorf("tag component should be restored")
+		}
+		if got := loaded.Parent(child); got != parent {
+			t.Errorf("unexpected result: got %v, want %v", got, parent)
+		}
+		if got, want := loaded.Stats().Entities, w.Stats().Entities; got.Used != want.Used || got.Recycled != want.Recycled {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("entity pool continues", func(t *testing.T) {
+		// arrange
+		w, _ := setup()
+		var buf bytes.Buffer
+		if err := w.Save(&buf); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := Load(&buf, components())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// act
+		want := w.CreateEntity()
+		got := loaded.CreateEntity()
+
+		// assert
+		if got != want {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("reserved entities", func(t *testing.T) {
+		// arrange
+		w, _ := setup()
+		reserved := w.Commands().CreateEntity(ComponentID(0))
+		var buf bytes.Buffer
+
+		// act
+		err := w.Save(&buf)
+		_, jsonErr := w.MarshalJSON()
+
+		// assert
+		// 予約済みのEntityが適用されるまでは保存できない
+		if !errors.Is(err, ErrReservedEntities) || buf.Len() != 0 {
+			t.Errorf("unexpected result: got %v, want %v", err, ErrReservedEntities)
+		}
+		if !errors.Is(jsonErr, ErrReservedEntities) {
+			t.Errorf("unexpected result: got %v, want %v", jsonErr, ErrReservedEntities)
+		}
+
+		// 適用後は保存でき、読み込んだWorldでも予約したEntityが生存している
+		w.Apply(w.Commands())
+		if err := w.Save(&buf); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := Load(&buf, components())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !loaded.Alive(reserved) || loaded.CreateEntity() == reserved {
+			t.Errorf("reserved entity should be saved")
+		}
+	})
+
+	t.Run("component mismatch", func(t *testing.T) {
+		// arrange
+		w, _ := setup()
+		var buf bytes.Buffer
+		if err := w.Save(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		// act
+		_, err := Load(&buf, components()[:1])
+
+		// assert
+		if !errors.Is(err, ErrSnapshotComponentMismatch) {
+			t.Errorf("unexpected result: got %v, want %v", err, ErrSnapshotComponentMismatch)
+		}
+	})
+
+	t.Run("unexported fields", func(t *testing.T) {
+		type health struct {
+			current, max int32
+		}
+		// arrange
+		w := NewWorld()
+		healthID := w.RegisterComponent(NewComponent[health]())
+		e := w.CreateEntity(healthID)
+		Set(w, e, health{current: 3, max: 5})
+		var buf bytes.Buffer
+		if err := w.Save(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		// act
+		loaded, err := Load(&buf, []component{NewComponent[health]()})
+
+		// assert
+		// ポインタを含まない型は、非公開のフィールドも保存される
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Get[health](loaded, e); *got != (health{current: 3, max: 5}) {
+			t.Errorf("unexpected result: got %v, want %v", *got, health{current: 3, max: 5})
+		}
+	})
+
+	t.Run("unserializable", func(t *testing.T) {
+		type Label struct {
+			Text  string
+			cache []byte
+		}
+		type Script struct {
+			Name string
+			Run  func()
+		}
+		tests := []struct {
+			name string
+			c    component
+		}{
+			{name: "unexported field", c: NewComponent[Label]()},
+			{name: "func field", c: NewComponent[Script]()},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// arrange
+				w := NewWorld()
+				id := w.RegisterComponent(tt.c)
+				w.CreateEntity(id)
+				var buf bytes.Buffer
+
+				// act
+				err := w.Save(&buf)
+
+				// assert
+				if !errors.Is(err, ErrUnserializableComponent) {
+					t.Errorf("unexpected result: got %v, want %v", err, ErrUnserializableComponent)
+				}
+				if buf.Len() != 0 {
+					t.Errorf("nothing should be written, but got %d bytes", buf.Len())
+				}
+			})
+		}
+	})
+
+	t.Run("invalid snapshot", func(t *testing.T) {
+		// act
+		_, err := Load(bytes.NewBufferString("JSON{}"), components())
+
+		// assert
+		if !errors.Is(err, ErrInvalidSnapshot) {
+			t.Errorf("unexpected result: got %v, want %v", err, ErrInvalidSnapshot)
+		}
+	})
+}
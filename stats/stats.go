@@ -28,7 +28,8 @@ func ToJSONPretty(stats World) []byte {
 
 // World : Worldの統計情報
 type World struct {
-	Entities Entities `json:"entities"` // Entityの統計情報
+	Entities   Entities   `json:"entities"`   // Entityの統計情報
+	Archetypes Archetypes `json:"archetypes"` // Archetypeの統計情報
 }
 
 // String : Worldの統計情報を文字列に変換します
@@ -36,6 +37,8 @@ func (w *World) String() string {
 	b := strings.Builder{}
 
 	fmt.Fprint(&b, w.Entities.String())
+	fmt.Fprint(&b, "\n")
+	fmt.Fprint(&b, w.Archetypes.String())
 
 	return b.String()
 }
@@ -56,3 +59,18 @@ type Entities struct {
 func (e *Entities) String() string {
 	return fmt.Sprintf("Entities: -- Used: %d, Recycled: %d, Total: %d, Capacity: %d --", e.Used, e.Recycled, e.Total, e.Capacity)
 }
+
+// Archetypes : Archetypeの統計情報
+type Archetypes struct {
+	// Count : 生成済みのArchetype数
+	Count int `json:"count"`
+	// EdgeCacheHits : Component追加、削除時に遷移先Archetypeのキャッシュがヒットした回数
+	EdgeCacheHits uint64 `json:"edgeCacheHits"`
+	// EdgeCacheMisses : Component追加、削除時に遷移先Archetypeのキャッシュがヒットしなかった回数
+	EdgeCacheMisses uint64 `json:"edgeCacheMisses"`
+}
+
+// String : Archetypeの統計情報を文字列に変換します
+func (a *Archetypes) String() string {
+	return fmt.Sprintf("Archetypes: -- Count: %d, EdgeCacheHits: %d, EdgeCacheMisses: %d --", a.Count, a.EdgeCacheHits, a.EdgeCacheMisses)
+}
@@ -0,0 +1,17 @@
+package ecsbit
+
+import "time"
+
+// System : Worldに対して処理を行うSystem
+type System interface {
+	// Update : Schedulerから呼び出され、Worldに対する処理を行います
+	Update(w *World, dt time.Duration)
+}
+
+// SystemFunc : 関数をSystemとして扱うための型
+type SystemFunc func(w *World, dt time.Duration)
+
+// Update : 関数を呼び出します
+func (f SystemFunc) Update(w *World, dt time.Duration) {
+	f(w, dt)
+}
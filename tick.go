@@ -0,0 +1,58 @@
+package ecsbit
+
+// Tick : 変更検知に利用する現在のTickを取得します
+// Componentの追加、変更は、その時点のTickで記録されます
+func (w *World) Tick() uint32 {
+	return w.tick.Load()
+}
+
+// AdvanceTick : Tickを1つ進め、進めた後のTickを返します
+// SchedulerはSystemを実行する度にTickを進めます. Schedulerを利用しない場合は、Systemの実行毎に呼び出してください
+func (w *World) AdvanceTick() uint32 {
+	return w.tick.Add(1)
+}
+
+// MarkChanged : Entityが持つComponentを、現在のTickで変更されたものとして記録します
+// Query走査中にポインタ経由で直接書き換えた場合など、Setを経由せずに変更した場合に呼び出してください.
+// 死んでいるEntityを指定した場合はErrDeadEntityOperation、Componentを持っていない場合はErrMissingComponentでpanicします
+func (w *World) MarkChanged(e Entity, id ComponentID) {
+	index, err := w.entityIndex(e)
+	if err != nil {
+		panic(err)
+	}
+	c := index.archetype.Column(id)
+	if c == nil {
+		panic(ErrMissingComponent)
+	}
+	c.MarkChanged(index.index, w.Tick())
+}
+
+// markChanged : Entityが持つComponentを現在のTickで変更されたものとして記録します（存在確認済みの場合に利用する）
+func (w *World) markChanged(e Entity, id ComponentID) {
+	index := &w.entityIndices[e.ID()]
+	index.archetype.Column(id).MarkChanged(index.index, w.Tick())
+}
+
+// SystemTicks : Systemが前回実行された時点のTickを保持するためのヘルパー
+// Systemに埋め込み、実行の開始時にUpdateを呼び出すことで、前回の実行以降に追加、変更されたComponentを絞り込めます.
+//
+//	func (s *SyncSystem) Update(w *ecsbit.World, dt time.Duration) {
+//		since := s.ticks.Update(w)
+//		q := w.Query(ecsbit.NewFilter().Changed(posID).Since(since))
+//		...
+//	}
+type SystemTicks struct {
+	last uint32 // 前回実行された時点のTick（一度も実行されていない場合は0）
+}
+
+// Last : 前回実行された時点のTickを取得します
+func (t *SystemTicks) Last() uint32 {
+	return t.last
+}
+
+// Update : 現在のTickを記録し、前回実行された時点のTickを返します
+func (t *SystemTicks) Update(w *World) uint32 {
+	last := t.last
+	t.last = w.Tick()
+	return last
+}
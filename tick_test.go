@@ -0,0 +1,229 @@
+package ecsbit
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWorld_ChangeDetection(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+
+	collect := func(q Query) []Entity {
+		entities := []Entity{}
+		for q.Next() {
+			entities = append(entities, q.Entity())
+		}
+		return entities
+	}
+
+	t.Run("added and changed", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		a := w.CreateEntity(posID)
+		b := w.CreateEntity(posID)
+		since := w.Tick()
+		w.AdvanceTick()
+
+		// act
+		c := w.CreateEntity(posID)
+		Set(w, b, Position{X: 1})
+		w.AddComponent(a, velID)
+
+		// assert
+		added := collect(w.Query(NewFilter().Added(posID).Since(since)))
+		if want := []Entity{c}; !slices.Equal(added, want) {
+			t.Errorf("unexpected result: got %v, want %v", added, want)
+		}
+		changed := slices.Sorted(slices.Values(collect(w.Query(NewFilter().Changed(posID).Since(since)))))
+		if want := []Entity{b, c}; !slices.Equal(changed, want) {
+			t.Errorf("unexpected result: got %v, want %v", changed, want)
+		}
+		// 移動しても既存のComponentのTickは引き継がれ、追加したComponentのみ追加扱いになる
+		velAdded := collect(w.Query(NewFilter().Added(velID).Since(since)))
+		if want := []Entity{a}; !slices.Equal(velAdded, want) {
+			t.Errorf("unexpected result: got %v, want %v", velAdded, want)
+		}
+		q := w.Query(NewFilter().Changed(posID).Since(since))
+		if got := q.Count(); got != 2 {
+			t.Errorf("unexpected result: got %v, want %v", got, 2)
+		}
+	})
+
+	t.Run("typed filters", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		w.CreateEntity(posID)
+		b := w.CreateEntity(posID, velID)
+		since := w.Tick()
+		w.AdvanceTick()
+
+		// act
+		c := w.CreateEntity(posID)
+		Set(w, b, Velocity{X: 1})
+
+		// assert
+		added := collect(w.Query(Added[Position](w, NewFilter()).Since(since)))
+		if want := []Entity{c}; !slices.Equal(added, want) {
+			t.Errorf("unexpected result: got %v, want %v", added, want)
+		}
+		changed := collect(w.Query(Changed[Velocity](w, NewFilter(posID)).Since(since)))
+		if want := []Entity{b}; !slices.Equal(changed, want) {
+			t.Errorf("unexpected result: got %v, want %v", changed, want)
+		}
+	})
+
+	t.Run("chunks with entity-level filters", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		w.CreateEntity(posID)
+		q := NewQuery1[Position](w).Changed(posID).Since(w.Tick())
+
+		// act & assert
+		defer func() {
+			r := recover()
+			if err, ok := r.(error); !ok || !errors.Is(err, ErrFilteredChunks) {
+				t.Errorf("unexpected result: got %v, want %v", r, ErrFilteredChunks)
+			}
+			if w.IsLocked() {
+				t.Errorf("world should not be locked")
+			}
+		}()
+		q.Chunks()
+	})
+
+	t.Run("mutable accessors", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		a, b, c := w.CreateEntity(posID), w.CreateEntity(posID), w.CreateEntity(posID)
+		since := w.AdvanceTick()
+		w.AdvanceTick()
+		field := NewField[Position](w)
+
+		// act
+		GetMut[Position](w, a).X = 1
+		Get[Position](w, b).X = 1
+		w.MarkChanged(c, posID)
+		q := w.Query(NewFilter(posID))
+		for q.Next() {
+			if q.Entity() == b {
+				field.GetMut(&q).X = 2
+			}
+		}
+
+		// assert
+		got := collect(w.Query(NewFilter().Changed(posID).Since(since)))
+		if want := []Entity{a, b, c}; !slices.Equal(got, want) {
+			t.Errorf("unexpected result: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("generated mutable accessors", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		a := w.CreateEntity(posID)
+		b := w.CreateEntity(posID, velID)
+		c := w.CreateEntity(posID, velID)
+		since := w.Tick()
+		w.AdvanceTick()
+
+		// act
+		// 参照のみのアクセサでは変更は記録されない
+		for _, pos := range NewQuery1[Position](w).All() {
+			pos.X = 1
+		}
+		for chunk := range NewQuery2[Position, Velocity](w).Chunks() {
+			chunk.B[0].X = 1
+		}
+		it := NewQuery1[Position](w).Iter()
+		for it.Next() {
+			if e, pos := it.Get(); e == a {
+				pos.X = 2
+			}
+		}
+		unchanged := collect(w.Query(NewFilter().Changed(posID).Since(since)))
+
+		it = NewQuery1[Position](w).Iter()
+		for it.Next() {
+			if it.Entity() == a {
+				_, pos := it.GetMut()
+				pos.X = 3
+			}
+		}
+		for e, row := range NewQuery2[Position, Velocity](w).AllMut() {
+			if e == b {
+				row.B.X = 2
+			}
+		}
+		w.AdvanceTick()
+		since2 := w.Tick()
+		w.AdvanceTick()
+		for chunk := range NewQuery2[Position, Velocity](w).ChunksMut() {
+			chunk.B[0].X = 3
+		}
+
+		// assert
+		if len(unchanged) != 0 {
+			t.Errorf("unexpected result: got %v, want %v", unchanged, []Entity{})
+		}
+		changed := slices.Sorted(slices.Values(collect(w.Query(NewFilter().Changed(posID).Since(since)))))
+		if want := []Entity{a, b, c}; !slices.Equal(changed, want) {
+			t.Errorf("unexpected result: got %v, want %v", changed, want)
+		}
+		chunked := slices.Sorted(slices.Values(collect(w.Query(NewFilter().Changed(velID).Since(since2)))))
+		if want := []Entity{b, c}; !slices.Equal(chunked, want) {
+			t.Errorf("unexpected result: got %v, want %v", chunked, want)
+		}
+	})
+
+	t.Run("system ticks", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		w.CreateEntity(posID)
+		var ticks SystemTicks
+		counts := []int{}
+		s := NewScheduler()
+		if err := s.AddSystem(StageUpdate, "observe", SystemFunc(func(w *World, dt time.Duration) {
+			since := ticks.Update(w)
+			q := w.Query(NewFilter().Changed(posID).Since(since))
+			counts = append(counts, q.Count())
+		})); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.AddSystem(StagePostUpdate, "spawn", SystemFunc(func(w *World, dt time.Duration) {
+			if len(counts) == 2 {
+				w.CreateEntity(posID)
+			}
+		})); err != nil {
+			t.Fatal(err)
+		}
+
+		// act
+		for range 4 {
+			if err := s.Update(w, time.Millisecond); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// assert
+		// 初回は全て、2回目は変更なし、3回目は前回の実行後に生成されたEntityのみ
+		if want := []int{1, 0, 1, 0}; !slices.Equal(counts, want) {
+			t.Errorf("unexpected result: got %v, want %v", counts, want)
+		}
+	})
+}
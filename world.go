@@ -1,6 +1,10 @@
 package ecsbit
 
 import (
+	"slices"
+	"sync/atomic"
+	"unsafe"
+
 	"github.com/atEaE/ecsbit/config"
 	"github.com/atEaE/ecsbit/internal/bits"
 	internalconfig "github.com/atEaE/ecsbit/internal/config"
@@ -21,14 +25,23 @@ func NewWorld(opts ...config.WorldConfigOption) *World {
 
 	world := &World{
 		componentStorage:  newComponentStorage(registeredComponentMaxSize),
-		archetypeData:     make([]archetypeData, 0, conf.ArchetypeDefaultCapacity),
-		archetypes:        make([]archetype, 0, conf.ArchetypeDefaultCapacity),
+		componentHooks:    newComponentHookStorage(),
+		resources:         newResourceStorage(),
+		removedLogs:       make([]*removedLog, registeredComponentMaxSize),
+		archetypeData:     make([]*archetypeData, 0, conf.ArchetypeDefaultCapacity),
+		archetypeLayouts:  make(map[bits.Mask256]*archetype, conf.ArchetypeDefaultCapacity),
+		relationSources:   make(map[relationKey][]Entity),
+		relationPositions: make(map[relationSource]int),
+		archetypes:        make([]*archetype, 0, conf.ArchetypeDefaultCapacity),
 		entityIndices:     make([]EntityIndex, 0, conf.EntityPoolDefaultCapacity),
 		entityPool:        newEntityPool(conf.EntityPoolDefaultCapacity),
-		onCreateCallbacks: make([]func(w *World, e Entity), 0, conf.OnCreateCallbacksDefaultCapacity),
-		onRemoveCallbacks: make([]func(w *World, e Entity), 0, conf.OnRemoveCallbacksDefaultCapacity),
+		onCreateCallbacks: newEntityCallbacks(conf.OnCreateCallbacksDefaultCapacity),
+		onRemoveCallbacks: newEntityCallbacks(conf.OnRemoveCallbacksDefaultCapacity),
 		config:            conf,
 	}
+	world.commands = NewCommandBuffer(world)
+	// Tick = 0は「まだ一度も実行していない」ことを表すため、1から開始する
+	world.tick.Store(1)
 	// entitiesに先頭sentinelを追加
 	// entity側もEntityID = 0がsentinelに該当するため、ID = Indexとして扱うこの仕様に合わせてsentinelを設定している
 	world.entityIndices = append(world.entityIndices, EntityIndex{index: 0, archetype: nil})
@@ -40,58 +53,181 @@ func NewWorld(opts ...config.WorldConfigOption) *World {
 
 // World : ECSの仕組みを提供する構造体
 type World struct {
-	componentStorage componentStorage            // Componentを管理するStorage
-	archetypeData    []archetypeData             // Archetypeから生成されたEntityのデータを保持するSlice
-	archetypeLayouts map[bits.Mask256]*archetype // LayoutMaskからArchetypeを取得するためのMap
-	archetypes       []archetype                 // Achetypeを管理するSlice
-	entityIndices    []EntityIndex               // Archetype内に置けるEntityIndexとArchetypeの関連性を管理する（EntityIDでIndexにアクセスする）
-	entityPool       entityPool                  // Entityを管理するPool（生成とリサイクルを管理する）
+	componentStorage  componentStorage            // Componentを管理するStorage
+	componentHooks    componentHookStorage        // ComponentID毎のライフサイクルのコールバックを管理するStorage
+	resources         resourceStorage             // Entityに紐付かないResourceを管理するStorage
+	removedLogs       []*removedLog               // ComponentIDをIndexとした削除の記録（記録しない場合はnil）
+	removedTracked    bits.Mask256                // 削除を記録するComponent
+	archetypeData     []*archetypeData            // Archetypeから生成されたEntityのデータを保持するSlice
+	archetypeLayouts  map[bits.Mask256]*archetype // LayoutMaskからArchetypeを取得するためのMap
+	relationSources   map[relationKey][]Entity    // RelationとTargetの組から、そのTargetを設定しているEntityを取得するためのMap
+	relationPositions map[relationSource]int      // EntityとRelationの組から、relationSources内での位置を取得するためのMap
+	archetypes        []*archetype                // Achetypeを管理するSlice（EntityIndexがポインタを保持するため、拡張時に移動しないようポインタで管理する）
+	entityIndices     []EntityIndex               // Archetype内に置けるEntityIndexとArchetypeの関連性を管理する（EntityIDでIndexにアクセスする）
+	entityPool        entityPool                  // Entityを管理するPool（生成とリサイクルを管理する）
+	cachedQueries     []*CachedQuery              // 登録中のCachedQuery（Archetype生成時にキャッシュを更新する）
+
+	edgeCacheHits   uint64 // Archetypeの遷移先キャッシュがヒットした回数
+	edgeCacheMisses uint64 // Archetypeの遷移先キャッシュがヒットしなかった回数
+
+	onCreateCallbacks  entityCallbacks // Entity生成時に呼び出すコールバック
+	onRemoveCallbacks  entityCallbacks // Entity削除時に呼び出すコールバック
+	lastCallbackHandle CallbackHandle  // 最後に発行したコールバックのハンドル
+
+	removing    bool     // Entityの削除処理中かどうか
+	removeQueue []Entity // 削除処理中に削除を要求されたEntity
+
+	observers      []*Observer // 登録中のObserver
+	observersDirty bool        // 解除されたObserverが残っているかどうか
+	notifying      int         // Observerへの通知中のネストの深さ
 
-	onCreateCallbacks []func(w *World, e Entity) // Entity生成時に呼び出すコールバック
-	onRemoveCallbacks []func(w *World, e Entity) // Entity削除時に呼び出すコールバック
+	commands *CommandBuffer // Schedulerが各Stageの終了時に適用するCommandBuffer
+	locks    atomic.Int32   // 走査中のQueryの数（並列実行中のSystemから更新されるためatomicに扱う）
+	tick     atomic.Uint32  // 変更検知に利用する現在のTick（並列実行中のSystemから更新されるためatomicに扱う）
 
 	config internalconfig.WorldConfig // Worldの設定（内部関数で使う場合があるので予め保持しておく）
 }
 
 // PushOnCreateCallback : Entity生成時に呼び出すコールバックを追加します。
 // 追加されたコールバックは、追加順に全てのEntity生成時に呼び出されます。
-// 追加順と特定のEntityに対する制御を加えたい場合は、コールバック内で制御してください
-func (w *World) PushOnCreateCallback(f func(w0 *World, e Entity)) {
-	w.onCreateCallbacks = append(w.onCreateCallbacks, f)
+// 特定のEntityに対する制御を加えたい場合は、コールバック内で制御してください.
+// 返却されたハンドルをRemoveCallbackに渡すことで登録を解除できます
+func (w *World) PushOnCreateCallback(f func(w0 *World, e Entity)) CallbackHandle {
+	return w.PushOnCreateCallbackWithPriority(f, 0)
+}
+
+// PushOnCreateCallbackWithPriority : 優先度を指定して、Entity生成時に呼び出すコールバックを追加します。
+// 優先度の高いものから順に呼び出され、同じ優先度の場合は追加順に呼び出されます
+func (w *World) PushOnCreateCallbackWithPriority(f func(w0 *World, e Entity), priority int) CallbackHandle {
+	handle := w.newCallbackHandle()
+	w.onCreateCallbacks.Push(entityCallback{handle: handle, priority: priority, fn: f})
+	return handle
 }
 
 // PushOnRemoveCallback : Entity削除時に呼び出すコールバックを追加します。
 // 追加されたコールバックは、追加順に全てのEntity削除時に呼び出されます。
-// 追加順と特定のEntityに対する制御を加えたい場合は、コールバック内で制御してください
-func (w *World) PushOnRemoveCallback(f func(w *World, e Entity)) {
-	w.onRemoveCallbacks = append(w.onRemoveCallbacks, f)
+// 特定のEntityに対する制御を加えたい場合は、コールバック内で制御してください.
+// 返却されたハンドルをRemoveCallbackに渡すことで登録を解除できます
+func (w *World) PushOnRemoveCallback(f func(w *World, e Entity)) CallbackHandle {
+	return w.PushOnRemoveCallbackWithPriority(f, 0)
+}
+
+// PushOnRemoveCallbackWithPriority : 優先度を指定して、Entity削除時に呼び出すコールバックを追加します。
+// 優先度の高いものから順に呼び出され、同じ優先度の場合は追加順に呼び出されます
+func (w *World) PushOnRemoveCallbackWithPriority(f func(w *World, e Entity), priority int) CallbackHandle {
+	handle := w.newCallbackHandle()
+	w.onRemoveCallbacks.Push(entityCallback{handle: handle, priority: priority, fn: f})
+	return handle
+}
+
+// RemoveCallback : 登録したコールバックを解除します. 登録されていない場合はfalseを返します
+// コールバックの呼び出し中に解除することもでき、その場合は以降のEntityに対して呼び出されなくなります
+func (w *World) RemoveCallback(handle CallbackHandle) bool {
+	return w.onCreateCallbacks.Remove(handle) || w.onRemoveCallbacks.Remove(handle)
+}
+
+// newCallbackHandle : 新しいコールバックのハンドルを発行します
+func (w *World) newCallbackHandle() CallbackHandle {
+	w.lastCallbackHandle++
+	return w.lastCallbackHandle
 }
 
 // CreateEntity : 新しいEntityを生成します
 func (w *World) CreateEntity(components ...ComponentID) Entity {
+	w.checkLocked()
 	return w.createEntity(w.findOrCreateArchetype(components))
 }
 
+// CreateEntities : 同じComponentを持つEntityを指定した数だけ生成し、生成したEntityを返します
+// Archetypeの解決と、Entity Pool、Archetypeの拡張を一度にまとめて行うため、CreateEntityを繰り返すより高速です.
+// OnAddやObserver、生成時のコールバックは、全てのEntityを生成した後に生成順に呼び出されます
+func (w *World) CreateEntities(n int, components ...ComponentID) []Entity {
+	w.checkLocked()
+	if n <= 0 {
+		return nil
+	}
+	archetype := w.findOrCreateArchetype(components)
+	entities := make([]Entity, n)
+	w.entityPool.GetN(entities)
+
+	start := archetype.AddN(entities, w.Tick())
+	maxID := EntityID(0)
+	for _, e := range entities {
+		maxID = max(maxID, e.ID())
+	}
+	w.growEntityIndices(maxID)
+	for i, e := range entities {
+		w.entityIndices[e.ID()] = EntityIndex{index: start + uint32(i), archetype: archetype}
+	}
+
+	for _, e := range entities {
+		// 先に呼び出したコールバック内で削除されている場合もあるので、生存しているものだけ呼び出す
+		if w.Alive(e) {
+			w.dispatchCreate(e, archetype)
+		}
+	}
+	return entities
+}
+
 // createEntity : Entityを生成します
 func (w *World) createEntity(archetype *archetype) Entity {
 	entity := w.entityPool.Get()
-	index := archetype.Add(entity)
-	w.entityIndices = append(w.entityIndices, EntityIndex{index: index, archetype: archetype})
+	w.spawn(entity, archetype)
+	return entity
+}
 
-	for i := range w.onCreateCallbacks {
-		w.onCreateCallbacks[i](w, entity)
+// spawn : Poolから取得したEntityをArchetypeに追加し、生成時のコールバックを呼び出します
+func (w *World) spawn(entity Entity, archetype *archetype) {
+	index := archetype.Add(entity, w.Tick())
+	w.setEntityIndex(entity.ID(), EntityIndex{index: index, archetype: archetype})
+	w.dispatchCreate(entity, archetype)
+}
+
+// dispatchCreate : 生成したEntityに対して、OnAdd、Observer、生成時のコールバックを順に呼び出します
+// 先に呼び出したコールバック内でEntityが削除された場合は、以降の呼び出しを行いません
+func (w *World) dispatchCreate(e Entity, archetype *archetype) {
+	w.fireOnAdd(e, &archetype.layoutMask)
+	if !w.Alive(e) {
+		return
+	}
+	w.notifyEnter(e, nil, archetype)
+	if !w.Alive(e) {
+		return
+	}
+	w.onCreateCallbacks.Dispatch(w, e)
+}
+
+// setEntityIndex : EntityIDに対応するEntityIndexを設定します
+// RecycleされたEntityIDを再利用した場合は既存のEntityIndexを上書きし、
+// 予約済みのEntityIDを含めて新しいEntityIDの場合は、そのIDまでEntityIndexを拡張します
+func (w *World) setEntityIndex(id EntityID, index EntityIndex) {
+	w.growEntityIndices(id)
+	w.entityIndices[id] = index
+}
+
+// growEntityIndices : 指定したEntityIDまでEntityIndexを拡張します
+func (w *World) growEntityIndices(id EntityID) {
+	if n := int(id) + 1 - len(w.entityIndices); n > 0 {
+		w.entityIndices = append(w.entityIndices, make([]EntityIndex, n)...)
 	}
-	return entity
 }
 
 // findOrCreateArchetype : 指定されたComponentIDからArchetypeを取得します
 // 存在しない場合は新しいArchetypeを生成します
 func (w *World) findOrCreateArchetype(components []ComponentID) *archetype {
 	if len(components) == 0 {
-		return &w.archetypes[noLayoutArchetypeIndex]
+		return w.archetypes[noLayoutArchetypeIndex]
+	}
+	for _, id := range components {
+		w.checkRegistered(id)
 	}
 
-	layout := createLayoutMask(components)
+	return w.findOrCreateArchetypeByLayout(createLayoutMask(components))
+}
+
+// findOrCreateArchetypeByLayout : 指定されたLayoutMaskからArchetypeを取得します
+// 存在しない場合は新しいArchetypeを生成します
+func (w *World) findOrCreateArchetypeByLayout(layout bits.Mask256) *archetype {
 	if archetype, ok := w.archetypeLayouts[layout]; ok {
 		return archetype
 	}
@@ -99,15 +235,180 @@ func (w *World) findOrCreateArchetype(components []ComponentID) *archetype {
 }
 
 // RemoveEntity : Entityを削除します
+// 削除前に、ComponentのOnRemoveとEntity削除時のコールバックを呼び出します.
+// コールバックの呼び出し時点ではEntityは生存しているため、Archetypeや各Componentのデータを参照できます.
+// コールバック内でRemoveEntityを呼び出した場合、その削除は現在の削除が完了した後に順に行われます
 func (w *World) RemoveEntity(e Entity) {
+	w.checkLocked()
 	// 死んでいるEntityをリサイクルするとpoolが破損するのでエラーを返す
-	if !w.entityPool.Alive(e) {
+	if !w.Alive(e) {
 		panic(ErrDeadEntityOperation)
 	}
 
-	// archetype周りの処理
+	// コールバック内からの削除は、EntityIndexの破損を防ぐためにキューに積んで後から処理する
+	if w.removing {
+		w.removeQueue = append(w.removeQueue, e)
+		return
+	}
+
+	w.beginRemove()
+	defer w.endRemove()
+
+	w.removeEntity(e)
+	w.drainRemoveQueue()
+}
+
+// RemoveMatching : Filterに一致する全てのEntityを削除し、削除したEntityの数を返します
+// Archetype単位で削除するため、RemoveEntityを繰り返すより高速です. OnRemoveやEntity削除時のコールバックは、
+// Archetype毎に全てのEntityに対して呼び出した後に削除します（呼び出し時点ではComponentのデータを参照できます）.
+// Added, Changed, WithRelationを指定したFilterの場合は、条件を満たすEntityを1件ずつ削除します.
+// コールバック内から呼び出した場合、削除は現在の削除が完了した後に順に行われます.
+// 返却する数はFilterに一致して削除したEntityの数で、CascadeRemoveによって連鎖して削除された子孫は含みません
+func (w *World) RemoveMatching(f Filter) int {
+	w.checkLocked()
+	if w.removing || f.filtered() {
+		return w.removeMatchingEach(f)
+	}
+
+	w.beginRemove()
+	defer w.endRemove()
+
+	count := 0
+	// コールバック内で生成されたArchetypeは対象外にする
+	archetypes := w.archetypes[:len(w.archetypes):len(w.archetypes)]
+	for _, a := range archetypes {
+		if a.Count() == 0 || !f.Matches(&a.layoutMask) {
+			continue
+		}
+		count += w.removeArchetypeEntities(a, &f)
+	}
+	w.drainRemoveQueue()
+	return count
+}
+
+// removeMatchingEach : Filterに一致するEntityを1件ずつ削除します
+func (w *World) removeMatchingEach(f Filter) int {
+	entities := []Entity{}
+	q := w.Query(f)
+	for q.Next() {
+		entities = append(entities, q.Entity())
+	}
+	for _, e := range entities {
+		w.RemoveEntity(e)
+	}
+	return len(entities)
+}
+
+// removeArchetypeEntities : Archetypeに属する全てのEntityを、コールバックを呼び出した上で削除します
+// コールバック内でArchetypeの構成が変わらなければ、Archetypeを一度に空にします
+func (w *World) removeArchetypeEntities(a *archetype, f *Filter) int {
+	// コールバックやRelationの解除でArchetypeにEntityが追加される可能性がある場合は、削除対象を複製しておく
+	entities, callbacks := a.entities, w.hasRemoveCallbacks(&a.layoutMask)
+	if callbacks || len(w.relationSources) > 0 {
+		entities = slices.Clone(entities)
+	}
+	if callbacks {
+		fired := make([]bool, len(entities))
+		for i, e := range entities {
+			if w.entityIndices[e.ID()].archetype == a && w.Alive(e) {
+				w.fireRemoveCallbacks(e)
+				fired[i] = true
+			}
+		}
+		if !slices.Equal(a.entities, entities) {
+			return w.removeMovedEntities(entities, fired, f)
+		}
+	}
+
+	if a.layoutMask.Intersects(&w.removedTracked) {
+		for i, e := range entities {
+			w.recordRemoved(e, &EntityIndex{index: uint32(i), archetype: a}, &a.layoutMask)
+		}
+	}
+	if a.layoutMask.Intersects(&w.componentStorage.Relations) {
+		for i, e := range entities {
+			w.unlinkRelations(e, &EntityIndex{index: uint32(i), archetype: a}, &a.layoutMask)
+		}
+	}
+	a.Clear()
+	for _, e := range entities {
+		w.entityPool.Recycle(e)
+		w.entityIndices[e.ID()].Clear()
+	}
+	if len(w.relationSources) > 0 {
+		for _, e := range entities {
+			w.releaseRelations(e)
+		}
+	}
+	return len(entities)
+}
+
+// beginRemove : Entityの削除処理を開始します
+func (w *World) beginRemove() {
+	w.removing = true
+}
+
+// endRemove : Entityの削除処理を終了します
+func (w *World) endRemove() {
+	clear(w.removeQueue)
+	w.removeQueue = w.removeQueue[:0]
+	w.removing = false
+}
+
+// drainRemoveQueue : 削除処理中に削除を要求されたEntityを順に削除します
+func (w *World) drainRemoveQueue() {
+	for i := 0; i < len(w.removeQueue); i++ {
+		// 同じEntityが複数回キューに積まれている場合もあるので、生存しているものだけ削除する
+		if queued := w.removeQueue[i]; w.Alive(queued) {
+			w.removeEntity(queued)
+		}
+	}
+}
+
+// hasRemoveCallbacks : layoutを持つEntityの削除時に呼び出すコールバックがあるかどうかを返します
+func (w *World) hasRemoveCallbacks(layout *bits.Mask256) bool {
+	return layout.Intersects(&w.componentHooks.onRem) || len(w.observers) > 0 || w.onRemoveCallbacks.Len() > 0
+}
+
+// fireRemoveCallbacks : Entityの削除時のコールバックを呼び出します
+func (w *World) fireRemoveCallbacks(e Entity) {
+	w.fireOnRemove(e, &w.entityIndices[e.ID()].archetype.layoutMask)
+	w.notifyExit(e, w.entityIndices[e.ID()].archetype, nil)
+	w.onRemoveCallbacks.Dispatch(w, e)
+}
+
+// removeMovedEntities : コールバック内でArchetypeの構成が変わった場合に、呼び出し前に属していたEntityを1件ずつ削除します
+// 別のArchetypeへ移動したEntityは、移動先でもFilterに一致する場合のみ削除し、コールバックを呼び出していなければ呼び出します
+func (w *World) removeMovedEntities(entities []Entity, fired []bool, f *Filter) int {
+	count := 0
+	for i, e := range entities {
+		if !w.Alive(e) || !f.Matches(&w.entityIndices[e.ID()].archetype.layoutMask) {
+			continue
+		}
+		if fired[i] {
+			w.destroyEntity(e)
+		} else {
+			w.removeEntity(e)
+		}
+		count++
+	}
+	return count
+}
+
+// removeEntity : コールバックを呼び出した上で、Entityを削除します
+func (w *World) removeEntity(e Entity) {
+	// Componentのデータを参照できるよう、削除前にコールバックを呼び出す
+	w.fireRemoveCallbacks(e)
+	w.destroyEntity(e)
+}
+
+// destroyEntity : コールバックを呼び出さずにEntityを削除します
+func (w *World) destroyEntity(e Entity) {
+	// コールバック内でComponentが追加、削除されている可能性があるため、EntityIndexはコールバックの後に参照する
 	index := &w.entityIndices[e.ID()]
 	oldArchetype := index.archetype
+	w.recordRemoved(e, index, &oldArchetype.layoutMask)
+	w.unlinkRelations(e, index, &oldArchetype.layoutMask)
 
 	swapped := oldArchetype.Remove(index.index)
 	w.entityPool.Recycle(e)
@@ -117,8 +418,234 @@ func (w *World) RemoveEntity(e Entity) {
 		w.entityIndices[swappedEntity.ID()].index = index.index
 	}
 	index.Clear()
+	w.releaseRelations(e)
+}
+
+// releaseRelations : 削除したEntityをTargetとしていたEntityを、設定に従って削除もしくはTargetが未設定の状態に戻します
+func (w *World) releaseRelations(e Entity) {
+	if len(w.relationSources) == 0 {
+		return
+	}
+	if w.config.CascadeRemove {
+		w.removeChildren(e)
+	}
+	w.releaseTarget(e)
+}
+
+// AddComponent : Entityに指定したComponentを追加します
+// 追加後のLayoutを持つArchetypeへEntityを移動し、既存のComponentのデータは引き継がれます.
+// 既に持っているComponentや、重複したComponentを指定した場合はErrDuplicateComponentでpanicします
+func (w *World) AddComponent(e Entity, components ...ComponentID) {
+	w.checkLocked()
+	index, err := w.entityIndex(e)
+	if err != nil {
+		panic(err)
+	}
+
+	source, target := index.archetype, index.archetype
+	for _, c := range components {
+		if target.HasComponent(c) {
+			panic(ErrDuplicateComponent)
+		}
+		target = w.findOrCreateArchetypeWith(target, c)
+	}
+	w.moveEntity(index, target)
+
+	added := diffLayoutMask(&target.layoutMask, &source.layoutMask)
+	w.fireOnAdd(e, &added)
+	// OnAddやObserverのonExit内でEntityが削除された場合は、以降の通知を行わない
+	if !w.Alive(e) {
+		return
+	}
+	w.notifyExit(e, source, target)
+	if !w.Alive(e) {
+		return
+	}
+	w.notifyEnter(e, source, target)
+}
+
+// RemoveComponent : Entityから指定したComponentを削除します
+// 削除後のLayoutを持つArchetypeへEntityを移動し、残りのComponentのデータは引き継がれます.
+// 持っていないComponentを指定した場合はErrMissingComponentでpanicします
+func (w *World) RemoveComponent(e Entity, components ...ComponentID) {
+	w.checkLocked()
+	index, err := w.entityIndex(e)
+	if err != nil {
+		panic(err)
+	}
+
+	removed := bits.Mask256{}
+	for _, c := range components {
+		if !index.archetype.HasComponent(c) || removed.Get(uint32(c)) {
+			panic(ErrMissingComponent)
+		}
+		removed.Set(uint32(c), true)
+	}
+
+	without := func(from *archetype) *archetype {
+		to := from
+		for _, c := range components {
+			if to.HasComponent(c) {
+				to = w.findOrCreateArchetypeWithout(to, c)
+			}
+		}
+		return to
+	}
+
+	// Componentのデータを参照できるよう、削除前にOnRemoveとObserverのonExitを呼び出す
+	// コールバック内でWorldが変更される可能性があるため、EntityIndexは呼び出しの度に取得し直す
+	w.fireOnRemove(e, &removed)
+	if index, err = w.entityIndex(e); err != nil {
+		return
+	}
+	source := index.archetype
+	target := without(source)
+	w.notifyExit(e, source, target)
+	if index, err = w.entityIndex(e); err != nil {
+		return
+	}
+	if index.archetype != source {
+		source = index.archetype
+		target = without(source)
+	}
+	gone := diffLayoutMask(&source.layoutMask, &target.layoutMask)
+	w.recordRemoved(e, index, &gone)
+	w.unlinkRelations(e, index, &gone)
+	w.moveEntity(index, target)
+
+	w.notifyEnter(e, source, target)
+}
+
+// findOrCreateArchetypeWith : 指定したArchetypeにComponentを追加した遷移先のArchetypeを取得します
+// 遷移先はArchetypeのedgeにキャッシュされるため、2回目以降はLayoutMaskの生成とMapの参照を行いません
+func (w *World) findOrCreateArchetypeWith(from *archetype, c ComponentID) *archetype {
+	w.checkRegistered(c)
+	edge := from.Edge(c)
+	if edge.add != nil {
+		w.edgeCacheHits++
+		return edge.add
+	}
+	w.edgeCacheMisses++
+
+	layout := from.layoutMask
+	layout.Set(uint32(c), true)
+	to := w.findOrCreateArchetypeByLayout(layout)
+	edge.add = to
+	to.Edge(c).remove = from
+	return to
+}
+
+// findOrCreateArchetypeWithout : 指定したArchetypeからComponentを削除した遷移先のArchetypeを取得します
+// 遷移先はArchetypeのedgeにキャッシュされるため、2回目以降はLayoutMaskの生成とMapの参照を行いません
+func (w *World) findOrCreateArchetypeWithout(from *archetype, c ComponentID) *archetype {
+	edge := from.Edge(c)
+	if edge.remove != nil {
+		w.edgeCacheHits++
+		return edge.remove
+	}
+	w.edgeCacheMisses++
+
+	layout := from.layoutMask
+	layout.Set(uint32(c), false)
+	to := w.findOrCreateArchetypeByLayout(layout)
+	edge.remove = to
+	to.Edge(c).add = from
+	return to
+}
 
-	// panic("not implemented")
+// moveEntity : Entityを別のArchetypeへ移動します
+// 移動先にも存在するComponentのデータはコピーし、移動元ではswap removeを行うため、入れ替わったEntityのEntityIndexも更新します
+func (w *World) moveEntity(index *EntityIndex, target *archetype) {
+	source, sourceIndex := index.archetype, index.index
+	if source == target {
+		return
+	}
+
+	e := source.GetEntity(sourceIndex)
+	targetIndex := target.Add(e, w.Tick())
+	for i, id := range target.componentIDs {
+		if c := source.Column(id); c != nil {
+			target.columns[i].CopyFrom(targetIndex, c, sourceIndex)
+		}
+	}
+
+	if source.Remove(sourceIndex) {
+		swappedEntity := source.GetEntity(sourceIndex)
+		w.entityIndices[swappedEntity.ID()].index = sourceIndex
+	}
+	index.archetype, index.index = target, targetIndex
+}
+
+// lock : Queryの走査開始を記録します
+func (w *World) lock() {
+	if w.config.IterationGuard {
+		w.locks.Add(1)
+	}
+}
+
+// unlock : Queryの走査終了を記録します
+func (w *World) unlock() {
+	if w.config.IterationGuard {
+		w.locks.Add(-1)
+	}
+}
+
+// IsLocked : Queryの走査中かどうかを返します
+func (w *World) IsLocked() bool {
+	return w.locks.Load() != 0
+}
+
+// checkLocked : Queryの走査中の場合はErrWorldLockedでpanicします
+func (w *World) checkLocked() {
+	if w.config.IterationGuard && w.IsLocked() {
+		panic(ErrWorldLocked)
+	}
+}
+
+// checkRegistered : Worldに登録されていないComponentIDの場合はErrUnregisteredComponentでpanicします
+func (w *World) checkRegistered(id ComponentID) {
+	if !w.componentStorage.Registered(id) {
+		panic(ErrUnregisteredComponent)
+	}
+}
+
+// Alive : Entityが生存しているかどうかを返します
+// CommandBufferで予約されたEntityは、CommandBufferを適用するまで生存していない扱いになります
+func (w *World) Alive(e Entity) bool {
+	if !w.entityPool.Alive(e) || int(e.ID()) >= len(w.entityIndices) {
+		return false
+	}
+	return w.entityIndices[e.ID()].archetype != nil
+}
+
+// entityIndex : 生存しているEntityのEntityIndexを取得します
+func (w *World) entityIndex(e Entity) (*EntityIndex, error) {
+	if !w.Alive(e) {
+		return nil, ErrDeadEntityOperation
+	}
+	return &w.entityIndices[e.ID()], nil
+}
+
+// get : Entityが持つComponentのデータを指すポインタを取得します
+func (w *World) get(e Entity, id ComponentID) (unsafe.Pointer, error) {
+	index, err := w.entityIndex(e)
+	if err != nil {
+		return nil, err
+	}
+	column := index.archetype.Column(id)
+	if column == nil {
+		return nil, ErrMissingComponent
+	}
+	return column.Get(index.index), nil
+}
+
+// has : EntityがComponentを持っているかどうかを返します
+func (w *World) has(e Entity, id ComponentID) (bool, error) {
+	index, err := w.entityIndex(e)
+	if err != nil {
+		return false, err
+	}
+	return index.archetype.HasComponent(id), nil
 }
 
 // RegisterComponent : Componentを登録します
@@ -130,9 +657,15 @@ func (w *World) RegisterComponent(c component) ComponentID {
 // createArchetype : Archetypeを生成します
 func (w *World) createArchetype(layoutMask bits.Mask256) *archetype {
 	idx := primitive.ArchetypeID(len(w.archetypes))
-	w.archetypeData = append(w.archetypeData, *newArchetypeData(w.config.EntityPoolDefaultCapacity, layoutMask))
-	w.archetypes = append(w.archetypes, *newArchetype(idx, &w.archetypeData[idx]))
-	return &w.archetypes[idx]
+	data := newArchetypeData(w.config.EntityPoolDefaultCapacity, layoutMask, w.componentStorage.Types)
+	archetype := newArchetype(idx, data)
+	w.archetypeData = append(w.archetypeData, data)
+	w.archetypes = append(w.archetypes, archetype)
+	w.archetypeLayouts[layoutMask] = archetype
+	for _, cq := range w.cachedQueries {
+		cq.match(archetype)
+	}
+	return archetype
 }
 
 // Stats : Worldの統計情報を取得します
@@ -144,10 +677,26 @@ func (w *World) Stats() *stats.World {
 			Capacity: w.entityPool.Cap(),
 			Recycled: w.entityPool.Available(),
 		},
+		Archetypes: stats.Archetypes{
+			Count:           len(w.archetypes),
+			EdgeCacheHits:   w.edgeCacheHits,
+			EdgeCacheMisses: w.edgeCacheMisses,
+		},
 	}
 	return stats
 }
 
+// diffLayoutMask : aに含まれ、bに含まれないComponentのLayoutMaskを生成します
+func diffLayoutMask(a, b *bits.Mask256) bits.Mask256 {
+	diff := bits.Mask256{}
+	ab, bb := a.Bits(), b.Bits()
+	db := diff.Bits()
+	for i := range ab {
+		db[i] = ab[i] &^ bb[i]
+	}
+	return diff
+}
+
 // createLayoutMask : 引数に指定されたComponentIDからLayoutMaskを生成します
 func createLayoutMask(components []ComponentID) bits.Mask256 {
 	mask := bits.Mask256{}
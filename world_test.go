@@ -1,6 +1,7 @@
 package ecsbit
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/atEaE/ecsbit/config"
@@ -12,10 +13,10 @@ func TestWorld_NewWorldWithOptions(t *testing.T) {
 		w := NewWorld()
 
 		// assert
-		if got := cap(w.onCreateCallbacks); got != int(config.Default().OnCreateCallbacksDefaultCapacity) {
+		if got := cap(w.onCreateCallbacks.callbacks); got != int(config.Default().OnCreateCallbacksDefaultCapacity) {
 			t.Errorf("unexpected result: got %v, want %v", got, int(config.Default().OnCreateCallbacksDefaultCapacity))
 		}
-		if got := cap(w.onRemoveCallbacks); got != int(config.Default().OnRemoveCallbacksDefaultCapacity) {
+		if got := cap(w.onRemoveCallbacks.callbacks); got != int(config.Default().OnRemoveCallbacksDefaultCapacity) {
 			t.Errorf("unexpected result: got %v, want %v", got, int(config.Default().OnRemoveCallbacksDefaultCapacity))
 		}
 	})
@@ -31,11 +32,453 @@ func TestWorld_NewWorldWithOptions(t *testing.T) {
 		)
 
 		// assert
-		if got := cap(w.onCreateCallbacks); got != int(expectedOnCreateCap) {
+		if got := cap(w.onCreateCallbacks.callbacks); got != int(expectedOnCreateCap) {
 			t.Errorf("unexpected result: got %v, want %v", got, int(expectedOnCreateCap))
 		}
-		if got := cap(w.onRemoveCallbacks); got != int(expectedOnRemoveCap) {
+		if got := cap(w.onRemoveCallbacks.callbacks); got != int(expectedOnRemoveCap) {
 			t.Errorf("unexpected result: got %v, want %v", got, int(expectedOnRemoveCap))
 		}
 	})
 }
+
+func TestWorld_AddRemoveComponent(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+
+	setup := func() (*World, ComponentID, ComponentID) {
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		return w, posID, velID
+	}
+
+	t.Run("add component keeps data", func(t *testing.T) {
+		// arrange
+		w, posID, velID := setup()
+		e1 := w.CreateEntity(posID)
+		e2 := w.CreateEntity(posID)
+		Set(w, e1, Position{X: 1})
+		Set(w, e2, Position{X: 2})
+
+		// act
+		w.AddComponent(e1, velID)
+
+		// assert
+		if !Has[Velocity](w, e1) {
+			t.Errorf("expected has Velocity, but not")
+		}
+		if got := Get[Position](w, e1).X; got != 1 {
+			t.Errorf("unexpected result: got %v, want %v", got, 1)
+		}
+		// swap removeで移動したEntityのIndexも更新されていること
+		if got := Get[Position](w, e2).X; got != 2 {
+			t.Errorf("unexpected result: got %v, want %v", got, 2)
+		}
+		if got := w.entityIndices[e1.ID()].archetype; got != w.findOrCreateArchetype([]ComponentID{posID, velID}) {
+			t.Errorf("unexpected archetype: %v", got.ID())
+		}
+	})
+
+	t.Run("remove component keeps data", func(t *testing.T) {
+		// arrange
+		w, posID, velID := setup()
+		e := w.CreateEntity(posID, velID)
+		Set(w, e, Position{X: 1})
+
+		// act
+		w.RemoveComponent(e, velID)
+
+		// assert
+		if Has[Velocity](w, e) {
+			t.Errorf("expected not has Velocity, but has")
+		}
+		if got := Get[Position](w, e).X; got != 1 {
+			t.Errorf("unexpected result: got %v, want %v", got, 1)
+		}
+	})
+
+	t.Run("add duplicate component", func(t *testing.T) {
+		// arrange
+		w, posID, _ := setup()
+		e := w.CreateEntity(posID)
+
+		// act & assert
+		defer func() {
+			err := recover()
+			if err == nil {
+				t.Errorf("expected panic, but not occurred")
+			}
+			if !errors.Is(err.(error), ErrDuplicateComponent) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+		w.AddComponent(e, posID)
+	})
+
+	t.Run("remove missing component", func(t *testing.T) {
+		// arrange
+		w, posID, velID := setup()
+		e := w.CreateEntity(posID)
+
+		// act & assert
+		defer func() {
+			err := recover()
+			if err == nil {
+				t.Errorf("expected panic, but not occurred")
+			}
+			if !errors.Is(err.(error), ErrMissingComponent) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+		w.RemoveComponent(e, velID)
+	})
+
+	t.Run("unregistered component", func(t *testing.T) {
+		// arrange
+		w, posID, _ := setup()
+		e := w.CreateEntity(posID)
+		unregistered := ComponentID(5)
+
+		for name, act := range map[string]func(){
+			"create": func() { w.CreateEntity(unregistered) },
+			"add":    func() { w.AddComponent(e, unregistered) },
+		} {
+			t.Run(name, func(t *testing.T) {
+				// act & assert
+				defer func() {
+					r := recover()
+					if err, ok := r.(error); !ok || !errors.Is(err, ErrUnregisteredComponent) {
+						t.Errorf("unexpected result: got %v, want %v", r, ErrUnregisteredComponent)
+					}
+				}()
+				act()
+			})
+		}
+	})
+}
+
+func TestWorld_ArchetypeEdgeCache(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+
+	// arrange
+	w := NewWorld()
+	posID := w.RegisterComponent(NewComponent[Position]())
+	velID := w.RegisterComponent(NewComponent[Velocity]())
+	e := w.CreateEntity(posID)
+
+	// act
+	w.AddComponent(e, velID)    // miss
+	w.RemoveComponent(e, velID) // hit (addの際に逆方向のedgeも登録される)
+	w.AddComponent(e, velID)    // hit
+
+	// assert
+	s := w.Stats()
+	if s.Archetypes.EdgeCacheMisses != 1 {
+		t.Errorf("unexpected misses: %d", s.Archetypes.EdgeCacheMisses)
+	}
+	if s.Archetypes.EdgeCacheHits != 2 {
+		t.Errorf("unexpected hits: %d", s.Archetypes.EdgeCacheHits)
+	}
+	if s.Archetypes.Count != 3 {
+		t.Errorf("unexpected archetype count: %d", s.Archetypes.Count)
+	}
+}
+
+func TestWorld_CreateEntities(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+
+	t.Run("reuse recycled entities", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		removed := w.CreateEntity(posID)
+		w.CreateEntity(posID)
+		w.RemoveEntity(removed)
+		created := []Entity{}
+		w.PushOnCreateCallback(func(w *World, e Entity) {
+			created = append(created, e)
+		})
+
+		// act
+		entities := w.CreateEntities(100, posID)
+
+		// assert
+		if len(entities) != 100 {
+			t.Fatalf("unexpected result: got %v, want %v", len(entities), 100)
+		}
+		if entities[0].ID() != removed.ID() || entities[0].Version() != removed.Version()+1 {
+			t.Errorf("unexpected result: got %v, want recycled %v", entities[0], removed)
+		}
+		for i, e := range entities {
+			if !w.Alive(e) || !Has[Position](w, e) {
+				t.Fatalf("entity %v should be alive with position", e)
+			}
+			Set(w, e, Position{X: float64(i)})
+		}
+		if got := Get[Position](w, entities[99]).X; got != 99 {
+			t.Errorf("unexpected result: got %v, want %v", got, 99)
+		}
+		if len(created) != 100 || created[99] != entities[99] {
+			t.Errorf("unexpected result: got %v callbacks", len(created))
+		}
+		if got := w.Stats().Entities.Used; got != 101 {
+			t.Errorf("unexpected result: got %v, want %v", got, 101)
+		}
+	})
+
+	t.Run("removed in callback", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		calls := 0
+		w.PushOnCreateCallback(func(w *World, e Entity) {
+			calls++
+			if calls == 1 {
+				w.RemoveEntity(NewEntity(e.ID() + 1))
+			}
+		})
+
+		// act
+		w.CreateEntities(3, posID)
+
+		// assert
+		if calls != 2 {
+			t.Errorf("unexpected result: got %v, want %v", calls, 2)
+		}
+	})
+}
+
+func TestWorld_RemoveMatching(t *testing.T) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+
+	setup := func() (*World, ComponentID, ComponentID) {
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		w.CreateEntities(10, posID)
+		w.CreateEntities(5, posID, velID)
+		w.CreateEntities(3, velID)
+		return w, posID, velID
+	}
+
+	t.Run("truncate archetypes", func(t *testing.T) {
+		// arrange
+		w, posID, velID := setup()
+
+		// act
+		removed := w.RemoveMatching(NewFilter(posID))
+
+		// assert
+		if removed != 15 {
+			t.Errorf("unexpected result: got %v, want %v", removed, 15)
+		}
+		q := w.Query(NewFilter(velID))
+		if got := q.Count(); got != 3 {
+			t.Errorf("unexpected result: got %v, want %v", got, 3)
+		}
+		if got := w.Stats().Entities.Used; got != 3 {
+			t.Errorf("unexpected result: got %v, want %v", got, 3)
+		}
+		// リサイクルしたEntityが再利用される
+		if e := w.CreateEntity(posID); e.Version() != 1 {
+			t.Errorf("unexpected result: got %v, want recycled entity", e)
+		}
+	})
+
+	t.Run("callbacks", func(t *testing.T) {
+		// arrange
+		w, posID, velID := setup()
+		w.TrackRemoved(posID, true)
+		values := 0
+		w.OnRemove(posID, func(w *World, e Entity) {
+			Get[Position](w, e) // 削除前はデータを参照できる
+			values++
+		})
+		removed := []Entity{}
+		w.PushOnRemoveCallback(func(w *World, e Entity) {
+			removed = append(removed, e)
+		})
+
+		// act
+		count := w.RemoveMatching(NewFilter(velID).Without(posID))
+		count += w.RemoveMatching(NewFilter(posID))
+
+		// assert
+		if count != 18 || len(removed) != 18 || values != 15 {
+			t.Errorf("unexpected result: got %v, %v, %v", count, len(removed), values)
+		}
+		n := 0
+		for range RemovedComponents[Position](w, 0) {
+			n++
+		}
+		if n != 15 {
+			t.Errorf("unexpected result: got %v, want %v", n, 15)
+		}
+	})
+
+	t.Run("structural change in callback", func(t *testing.T) {
+		// arrange
+		w, posID, velID := setup()
+		w.PushOnRemoveCallback(func(w *World, e Entity) {
+			if Has[Velocity](w, e) {
+				w.RemoveComponent(e, velID)
+			}
+		})
+
+		// act
+		count := w.RemoveMatching(NewFilter(posID))
+
+		// assert
+		if count != 15 {
+			t.Errorf("unexpected result: got %v, want %v", count, 15)
+		}
+		if got := w.Stats().Entities.Used; got != 3 {
+			t.Errorf("unexpected result: got %v, want %v", got, 3)
+		}
+	})
+
+	t.Run("entity moved out in callback", func(t *testing.T) {
+		// arrange
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		velID := w.RegisterComponent(NewComponent[Velocity]())
+		e1 := w.CreateEntity(posID, velID)
+		e2 := w.CreateEntity(posID, velID)
+		removed := []Entity{}
+		w.PushOnRemoveCallback(func(w *World, e Entity) {
+			removed = append(removed, e)
+			if e == e1 {
+				w.RemoveComponent(e2, posID)
+			}
+		})
+
+		// act
+		count := w.RemoveMatching(NewFilter(posID))
+
+		// assert
+		// Filterに一致しなくなったEntityは削除されず、コールバックも呼び出されない
+		if count != 1 || len(removed) != 1 || w.Alive(e1) {
+			t.Errorf("unexpected result: got %v, %v", count, removed)
+		}
+		if !w.Alive(e2) || !Has[Velocity](w, e2) {
+			t.Errorf("moved entity should be alive")
+		}
+	})
+
+	t.Run("cascade", func(t *testing.T) {
+		// arrange
+		w := NewWorld(config.WithCascadeRemove(true))
+		posID := w.RegisterComponent(NewComponent[Position]())
+		parents := w.CreateEntities(2, posID)
+		for _, child := range w.CreateEntities(4) {
+			w.SetParent(child, parents[0])
+		}
+
+		// act
+		w.RemoveMatching(NewFilter(posID))
+
+		// assert
+		if got := w.Stats().Entities.Used; got != 0 {
+			t.Errorf("unexpected result: got %v, want %v", got, 0)
+		}
+	})
+}
+
+func BenchmarkWorld_RemoveEntity(b *testing.B) {
+	type Position struct {
+		X, Y float64
+	}
+
+	w := NewWorld()
+	posID := w.RegisterComponent(NewComponent[Position]())
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		entities := w.CreateEntities(10000, posID)
+		b.StartTimer()
+		for _, e := range entities {
+			w.RemoveEntity(e)
+		}
+	}
+}
+
+func BenchmarkWorld_RemoveMatching(b *testing.B) {
+	type Position struct {
+		X, Y float64
+	}
+
+	w := NewWorld()
+	posID := w.RegisterComponent(NewComponent[Position]())
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		w.CreateEntities(10000, posID)
+		b.StartTimer()
+		w.RemoveMatching(NewFilter(posID))
+	}
+}
+
+func BenchmarkWorld_CreateEntity(b *testing.B) {
+	type Position struct {
+		X, Y float64
+	}
+
+	for i := 0; i < b.N; i++ {
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		for range 10000 {
+			w.CreateEntity(posID)
+		}
+	}
+}
+
+func BenchmarkWorld_CreateEntities(b *testing.B) {
+	type Position struct {
+		X, Y float64
+	}
+
+	for i := 0; i < b.N; i++ {
+		w := NewWorld()
+		posID := w.RegisterComponent(NewComponent[Position]())
+		w.CreateEntities(10000, posID)
+	}
+}
+
+func BenchmarkWorld_AddRemoveComponent(b *testing.B) {
+	type Position struct {
+		X, Y float64
+	}
+	type Velocity struct {
+		X, Y float64
+	}
+
+	w := NewWorld()
+	posID := w.RegisterComponent(NewComponent[Position]())
+	velID := w.RegisterComponent(NewComponent[Velocity]())
+	e := w.CreateEntity(posID)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.AddComponent(e, velID)
+		w.RemoveComponent(e, velID)
+	}
+	b.StopTimer()
+
+	s := w.Stats()
+	b.ReportMetric(float64(s.Archetypes.EdgeCacheHits), "edge-hits")
+	b.ReportMetric(float64(s.Archetypes.EdgeCacheMisses), "edge-misses")
+}